@@ -0,0 +1,222 @@
+package filterable
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+func (items *Filterable) Aggregate(seed interface{}, accumulator func(interface{}, interface{}) interface{}) interface{} {
+	result := seed
+
+	for _, item := range *items {
+		result = accumulator(result, item)
+	}
+
+	return result
+}
+
+func (items *Filterable) AggregateWithResult(seed interface{}, accumulator func(interface{}, interface{}) interface{}, resultSelector func(interface{}) interface{}) interface{} {
+	return resultSelector(items.Aggregate(seed, accumulator))
+}
+
+// Scan is Aggregate that keeps every intermediate accumulator, e.g. running totals.
+func (items *Filterable) Scan(seed interface{}, accumulator func(interface{}, interface{}) interface{}) *Filterable {
+	projection := make(Filterable, 0, len(*items))
+
+	result := seed
+
+	for _, item := range *items {
+		result = accumulator(result, item)
+		projection = append(projection, result)
+	}
+
+	return &projection
+}
+
+func (query *Lazy) Aggregate(seed interface{}, accumulator func(interface{}, interface{}) interface{}) interface{} {
+	result := seed
+
+	next := query.start()
+
+	for item, ok := next(); ok; item, ok = next() {
+		result = accumulator(result, item)
+	}
+
+	return result
+}
+
+func (query *Lazy) Scan(seed interface{}, accumulator func(interface{}, interface{}) interface{}) *Lazy {
+	return query.stage(func(source iterator) iterator {
+		result := seed
+
+		return func() (interface{}, bool) {
+			item, ok := source()
+
+			if !ok {
+				return nil, false
+			}
+
+			result = accumulator(result, item)
+			return result, true
+		}
+	})
+}
+
+// Sum adds numeric elements of any kind. The total is a float64 whatever the
+// element kinds are, so integer totals beyond 2^53 lose precision; use SumInt
+// to add integers exactly. An empty sequence sums to zero.
+func (items *Filterable) Sum() (float64, error) {
+	return items.SumBy(identity)
+}
+
+func (items *Filterable) SumBy(selector func(interface{}) interface{}) (float64, error) {
+	total := 0.0
+
+	for index, item := range *items {
+		value, err := toNumber(index, selector(item))
+
+		if err != nil {
+			return 0, err
+		}
+
+		total += value
+	}
+
+	return total, nil
+}
+
+// SumInt adds integer elements of any kind exactly, returning ErrNotNumeric
+// for any other element and ErrOverflow when the total does not fit an int64.
+func (items *Filterable) SumInt() (int64, error) {
+	return items.SumIntBy(identity)
+}
+
+func (items *Filterable) SumIntBy(selector func(interface{}) interface{}) (int64, error) {
+	var total int64
+
+	for index, item := range *items {
+		value, err := toInt(index, selector(item))
+
+		if err != nil {
+			return 0, err
+		}
+
+		sum := total + value
+
+		if (value > 0 && sum < total) || (value < 0 && sum > total) {
+			return 0, fmt.Errorf("%w: sum at element %d", ErrOverflow, index)
+		}
+
+		total = sum
+	}
+
+	return total, nil
+}
+
+func (items *Filterable) Average() (float64, error) {
+	return items.AverageBy(identity)
+}
+
+func (items *Filterable) AverageBy(selector func(interface{}) interface{}) (float64, error) {
+	if len(*items) == 0 {
+		return 0, ErrNoElements
+	}
+
+	total, err := items.SumBy(selector)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return total / float64(len(*items)), nil
+}
+
+// Min returns the smallest of numeric elements of any kind, or ErrNotNumeric
+// if any element is not a number.
+func (items *Filterable) Min() (interface{}, error) {
+	if err := items.checkNumeric(); err != nil {
+		return nil, err
+	}
+
+	return items.MinBy(identity)
+}
+
+// MinBy returns the element with the smallest key, the first one on ties.
+// Keys need not be numeric; they are compared the same way as OrderBy keys.
+func (items *Filterable) MinBy(selector func(interface{}) interface{}) (interface{}, error) {
+	return items.extremeBy(selector, -1)
+}
+
+// Max returns the largest of numeric elements of any kind, or ErrNotNumeric if
+// any element is not a number.
+func (items *Filterable) Max() (interface{}, error) {
+	if err := items.checkNumeric(); err != nil {
+		return nil, err
+	}
+
+	return items.MaxBy(identity)
+}
+
+// MaxBy returns the element with the largest key, the first one on ties.
+// Keys need not be numeric; they are compared the same way as OrderBy keys.
+func (items *Filterable) MaxBy(selector func(interface{}) interface{}) (interface{}, error) {
+	return items.extremeBy(selector, 1)
+}
+
+func (items *Filterable) extremeBy(selector func(interface{}) interface{}, direction int) (interface{}, error) {
+	if len(*items) == 0 {
+		return nil, ErrNoElements
+	}
+
+	extreme, extremeKey := (*items)[0], selector((*items)[0])
+
+	for _, item := range (*items)[1:] {
+		if key := selector(item); compareKeys(key, extremeKey) == direction {
+			extreme, extremeKey = item, key
+		}
+	}
+
+	return extreme, nil
+}
+
+func (items *Filterable) checkNumeric() error {
+	for index, item := range *items {
+		if _, err := toNumber(index, item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func toNumber(index int, value interface{}) (float64, error) {
+	if value == nil {
+		return 0, fmt.Errorf("%w: element %d is nil", ErrNotNumeric, index)
+	}
+
+	if number := reflect.ValueOf(value); isNumber(number) {
+		return toFloat(number), nil
+	}
+
+	return 0, fmt.Errorf("%w: element %d is %T", ErrNotNumeric, index, value)
+}
+
+func toInt(index int, value interface{}) (int64, error) {
+	number := reflect.ValueOf(value)
+
+	switch {
+	case value == nil:
+		return 0, fmt.Errorf("%w: element %d is nil", ErrNotNumeric, index)
+	case isSigned(number):
+		return number.Int(), nil
+	case isUnsigned(number):
+		if number.Uint() > math.MaxInt64 {
+			return 0, fmt.Errorf("%w: element %d is %v", ErrOverflow, index, value)
+		}
+
+		return int64(number.Uint()), nil
+	}
+
+	return 0, fmt.Errorf("%w: element %d is %T, not an integer", ErrNotNumeric, index, value)
+}
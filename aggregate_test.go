@@ -0,0 +1,354 @@
+package filterable
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func sum(acc interface{}, value interface{}) interface{} {
+	return acc.(int) + value.(int)
+}
+
+func Test_Filterable_Aggregate(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when an empty slice is given",
+			input:    emptyInput,
+			expected: format_any(10),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.Aggregate(10, sum)), err
+			},
+		},
+		{
+			name:     "when summing values",
+			input:    sliceInput,
+			expected: format_any(28),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.Aggregate(0, sum)), err
+			},
+		},
+		{
+			name:     "when folding into a different type",
+			input:    []string{"a", "b", "c"},
+			expected: format_any("c,b,a"),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				result := collection.Aggregate("", func(acc interface{}, value interface{}) interface{} {
+					if acc == "" {
+						return value
+					}
+					return value.(string) + "," + acc.(string)
+				})
+				return format_any(result), err
+			},
+		},
+		{
+			name:     "when a result selector is given",
+			input:    sliceInput,
+			expected: format_any(4),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				result := collection.AggregateWithResult(0, sum, func(total interface{}) interface{} {
+					return total.(int) / collection.Count()
+				})
+				return format_any(result), err
+			},
+		},
+		{
+			name:     "when aggregating a lazy query",
+			input:    10,
+			expected: format_any(55),
+			action: func(input interface{}) (string, error) {
+				return format_any(LazyRange(1, input.(int)).Aggregate(0, sum)), nil
+			},
+		},
+	}
+
+	run_tests_on("Aggregate", scenarios, t)
+}
+
+func Test_Filterable_Scan(t *testing.T) {
+	max := func(acc interface{}, value interface{}) interface{} {
+		if value.(int) > acc.(int) {
+			return value
+		}
+		return acc
+	}
+
+	scenarios := []testScenario{
+		{
+			name:     "when an empty slice is given",
+			input:    emptyInput,
+			expected: format_any([]int{}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.Scan(0, sum).Unwrap()), err
+			},
+		},
+		{
+			name:     "when computing running totals",
+			input:    sliceInput,
+			expected: format_any([]int{1, 3, 6, 10, 15, 21, 28}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.Scan(0, sum).Unwrap()), err
+			},
+		},
+		{
+			name:     "when computing a cumulative max",
+			input:    []int{3, 1, 4, 1, 5, 9, 2},
+			expected: format_any([]int{3, 3, 4, 4, 5, 9, 9}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.Scan(0, max).Unwrap()), err
+			},
+		},
+		{
+			name:     "when scanning a lazy query",
+			input:    10_000_000,
+			expected: format_any([]int{1, 3, 6}),
+			action: func(input interface{}) (string, error) {
+				return format_any(LazyRange(1, input.(int)).Scan(0, sum).Take(3).Unwrap()), nil
+			},
+		},
+	}
+
+	run_tests_on("Scan", scenarios, t)
+}
+
+func Test_Filterable_Sum(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when an empty slice is given",
+			input:    emptyInput,
+			expected: format_any(0.0),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				total, err := collection.Sum()
+				return format_any(total), err
+			},
+		},
+		{
+			name:     "when mixed numeric kinds are given",
+			input:    []interface{}{1, int8(-2), uint16(3), float32(0.5), 1.5},
+			expected: format_any(4.0),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				total, err := collection.Sum()
+				return format_any(total), err
+			},
+		},
+		{
+			name:     "when summing by a selector",
+			input:    employees,
+			expected: format_any(152.0),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				total, err := collection.SumBy(func(value interface{}) interface{} {
+					return value.(employee).Age
+				})
+				return format_any(total), err
+			},
+		},
+		{
+			name:     "when a non-numeric value is given",
+			input:    []interface{}{1, "2"},
+			expected: format_any("value is not numeric: element 1 is string"),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				_, err := collection.Sum()
+				return format_any(err), nil
+			},
+		},
+	}
+
+	run_tests_on("Sum", scenarios, t)
+}
+
+func Test_Filterable_SumInt(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when integers exceed float64 precision",
+			input:    []int64{math.MaxInt64 - 1, 0},
+			expected: format_any(int64(math.MaxInt64 - 1)),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				total, err := collection.SumInt()
+				return format_any(total), err
+			},
+		},
+		{
+			name:     "when mixed integer kinds are given",
+			input:    []interface{}{1, int8(-2), uint16(3), uint64(4)},
+			expected: format_any(int64(6)),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				total, err := collection.SumInt()
+				return format_any(total), err
+			},
+		},
+		{
+			name:     "when summing by a selector",
+			input:    employees,
+			expected: format_any(int64(152)),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				total, err := collection.SumIntBy(func(value interface{}) interface{} {
+					return value.(employee).Age
+				})
+				return format_any(total), err
+			},
+		},
+		{
+			name:     "when the total overflows",
+			input:    []int64{math.MaxInt64, 1},
+			expected: format_any([]interface{}{"integer overflow: sum at element 1", true}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				_, err := collection.SumInt()
+				return format_any([]interface{}{err, errors.Is(err, ErrOverflow)}), nil
+			},
+		},
+		{
+			name:     "when an element is too large",
+			input:    []uint64{math.MaxUint64},
+			expected: format_any("integer overflow: element 0 is 18446744073709551615"),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				_, err := collection.SumInt()
+				return format_any(err), nil
+			},
+		},
+		{
+			name:     "when a float is given",
+			input:    []interface{}{1, 1.5},
+			expected: format_any("value is not numeric: element 1 is float64, not an integer"),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				_, err := collection.SumInt()
+				return format_any(err), nil
+			},
+		},
+	}
+
+	run_tests_on("SumInt", scenarios, t)
+}
+
+func Test_Filterable_Average(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when an empty slice is given",
+			input:    emptyInput,
+			expected: format_any(ErrNoElements),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				_, err := collection.Average()
+				return format_any(err), nil
+			},
+		},
+		{
+			name:     "when averaging values",
+			input:    sliceInput,
+			expected: format_any(4.0),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				average, err := collection.Average()
+				return format_any(average), err
+			},
+		},
+		{
+			name:     "when averaging by a selector",
+			input:    []int{1, 2},
+			expected: format_any(0.75),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				average, err := collection.AverageBy(func(value interface{}) interface{} {
+					return float64(value.(int)) / 2
+				})
+				return format_any(average), err
+			},
+		},
+		{
+			name:     "when a nil value is given",
+			input:    []interface{}{nil},
+			expected: format_any("value is not numeric: element 0 is nil"),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				_, err := collection.Average()
+				return format_any(err), nil
+			},
+		},
+	}
+
+	run_tests_on("Average", scenarios, t)
+}
+
+func Test_Filterable_MinMax(t *testing.T) {
+	age := func(value interface{}) interface{} {
+		return value.(employee).Age
+	}
+
+	scenarios := []testScenario{
+		{
+			name:     "when an empty slice is given",
+			input:    emptyInput,
+			expected: format_any([]error{ErrNoElements, ErrNoElements}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				_, minErr := collection.Min()
+				_, maxErr := collection.Max()
+				return format_any([]error{minErr, maxErr}), nil
+			},
+		},
+		{
+			name:     "when finding the extremes of numbers",
+			input:    []int{3, -10, 9, 100, 1},
+			expected: format_any([]int{-10, 100}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				min, _ := collection.Min()
+				max, err := collection.Max()
+				return format_any([]interface{}{min, max}), err
+			},
+		},
+		{
+			name:     "when a non-numeric value is given",
+			input:    []interface{}{"a", 1},
+			expected: format_any([]string{"value is not numeric: element 0 is string", "value is not numeric: element 0 is string"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				_, minErr := collection.Min()
+				_, maxErr := collection.Max()
+				return format_any([]string{minErr.Error(), maxErr.Error()}), nil
+			},
+		},
+		{
+			name:     "when finding the elements with extreme keys",
+			input:    employees,
+			expected: format_any([]employee{{"IT", "Jones", 22}, {"Sales", "Smith", 40}}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				youngest, _ := collection.MinBy(age)
+				oldest, err := collection.MaxBy(age)
+				return format_any([]interface{}{youngest, oldest}), err
+			},
+		},
+		{
+			name:     "when several elements share the extreme key",
+			input:    employees,
+			expected: format_any(employee{"IT", "Jones", 30}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				first, err := collection.MinBy(func(value interface{}) interface{} {
+					return value.(employee).Department
+				})
+				return format_any(first), err
+			},
+		},
+	}
+
+	run_tests_on("MinMax", scenarios, t)
+}
@@ -0,0 +1,71 @@
+package filterable
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// FromChannel returns a lazy query that receives from ch, which must be a
+// channel that can be received from, until it is closed. Elements are
+// received only as the query pulls them, and each one is received once, so
+// a second terminal operation continues where the first one stopped. A
+// receive blocked on an idle channel gives up once the query's context is
+// done.
+func FromChannel(ch interface{}) (*Lazy, error) {
+	channel := reflect.ValueOf(ch)
+
+	if channel.Kind() != reflect.Chan || channel.Type().ChanDir()&reflect.RecvDir == 0 {
+		return nil, fmt.Errorf("argument not a valid receive channel")
+	}
+
+	return &Lazy{iterate: func(ctx context.Context) iterator {
+		// a nil Done channel is never ready, so the case is simply never chosen
+		cases := []reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: channel},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+		}
+
+		return func() (interface{}, bool) {
+			chosen, item, ok := reflect.Select(cases)
+
+			if chosen != 0 || !ok {
+				return nil, false
+			}
+
+			return item.Interface(), true
+		}
+	}}, nil
+}
+
+// ToChannel runs the query on a new goroutine and sends its elements on the
+// returned channel, which is closed when the query is exhausted or ctx is done.
+func (query *Lazy) ToChannel(ctx context.Context, buffer int) <-chan interface{} {
+	out := make(chan interface{}, buffer)
+
+	go func() {
+		defer close(out)
+
+		next := query.iterate(ctx)
+
+		for ctx.Err() == nil {
+			item, ok := next()
+
+			if !ok {
+				return
+			}
+
+			select {
+			case out <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+func (items *Filterable) ToChannel(ctx context.Context, buffer int) <-chan interface{} {
+	return items.AsLazy().ToChannel(ctx, buffer)
+}
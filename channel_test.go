@@ -0,0 +1,151 @@
+package filterable
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func Test_Lazy_FromChannel(t *testing.T) {
+	produce := func(values ...int) chan int {
+		ch := make(chan int, len(values))
+		for _, value := range values {
+			ch <- value
+		}
+		close(ch)
+		return ch
+	}
+
+	scenarios := []testScenario{
+		{
+			name:     "when a closed channel is given",
+			input:    produce(1, 2, 3, 4),
+			expected: format_any([]int{2, 4}),
+			action: func(input interface{}) (string, error) {
+				query, err := FromChannel(input)
+				return format_any(query.Where(func(value interface{}) bool {
+					return value.(int)%2 == 0
+				}).Unwrap()), err
+			},
+		},
+		{
+			name:     "when only part of the channel is taken",
+			input:    produce(1, 2, 3, 4),
+			expected: format_any([]interface{}{[]int{1, 2}, []int{3, 4}}),
+			action: func(input interface{}) (string, error) {
+				query, err := FromChannel(input)
+				first := query.Take(2).Unwrap()
+				rest := query.Unwrap()
+				return format_any([]interface{}{first, rest}), err
+			},
+		},
+		{
+			name:     "when an open channel is taken from",
+			input:    make(chan string),
+			expected: format_any([]string{"a", "b"}),
+			action: func(input interface{}) (string, error) {
+				ch := input.(chan string)
+				go func() {
+					ch <- "a"
+					ch <- "b"
+				}()
+				query, err := FromChannel((<-chan string)(ch))
+				return format_any(query.Take(2).Unwrap()), err
+			},
+		},
+		{
+			name:     "when a send-only channel is given",
+			input:    make(chan<- int),
+			expected: format_any("argument not a valid receive channel"),
+			action: func(input interface{}) (string, error) {
+				_, err := FromChannel(input)
+				return format_any(err), nil
+			},
+		},
+		{
+			name:     "when a slice is given",
+			input:    sliceInput,
+			expected: format_any("argument not a valid receive channel"),
+			action: func(input interface{}) (string, error) {
+				_, err := FromChannel(input)
+				return format_any(err), nil
+			},
+		},
+	}
+
+	run_tests_on("FromChannel", scenarios, t)
+}
+
+func Test_Lazy_ToChannel(t *testing.T) {
+	drain := func(ch <-chan interface{}) Filterable {
+		received := Filterable{}
+		for item := range ch {
+			received = append(received, item)
+		}
+		return received
+	}
+
+	scenarios := []testScenario{
+		{
+			name:     "when the query is exhausted",
+			input:    sliceInput,
+			expected: format_any([]int{2, 4, 6}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				query := collection.AsLazy().Where(func(value interface{}) bool {
+					return value.(int)%2 == 0
+				})
+				return format_any(drain(query.ToChannel(context.Background(), 0))), err
+			},
+		},
+		{
+			name:     "when the context is cancelled",
+			input:    10_000_000,
+			expected: format_any([]int{1, 2, 3}),
+			action: func(input interface{}) (string, error) {
+				ctx, cancel := context.WithCancel(context.Background())
+				ch := LazyRange(1, input.(int)).ToChannel(ctx, 0)
+				received := Filterable{<-ch, <-ch, <-ch}
+				cancel()
+				drain(ch)
+				return format_any(received), nil
+			},
+		},
+		{
+			name:     "when the context is cancelled while the upstream is blocked",
+			input:    make(chan int),
+			expected: "closed",
+			action: func(input interface{}) (string, error) {
+				ctx, cancel := context.WithCancel(context.Background())
+				query, err := FromChannel(input)
+				ch := query.Where(func(interface{}) bool { return false }).ToChannel(ctx, 0)
+				time.AfterFunc(20*time.Millisecond, cancel)
+				select {
+				case item, ok := <-ch:
+					if ok {
+						return format_any(item), err
+					}
+					return "closed", err
+				case <-time.After(time.Second):
+					return "still open", err
+				}
+			},
+		},
+		{
+			name:     "when piping one channel into another",
+			input:    sliceInput,
+			expected: format_any([]int{10, 20, 30, 40, 50, 60, 70}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				source := collection.ToChannel(context.Background(), 2)
+				query, err := FromChannel(source)
+				doubled := query.Select(func(value interface{}) interface{} {
+					return value.(int) * 10
+				}).ToChannel(context.Background(), 2)
+				return format_any(drain(doubled)), err
+			},
+		},
+	}
+
+	run_tests_on("ToChannel", scenarios, t)
+}
@@ -0,0 +1,252 @@
+package filterable
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// compareKeys orders two sort keys and returns -1, 0 or 1.
+//
+// Keys are first grouped: nil, then numbers of every kind, strings, bools,
+// time.Time values and finally everything else. Within a group, integers,
+// unsigned integers and floats compare numerically with each other (NaN sorts
+// before every other number), strings compare lexically, false sorts before
+// true and time.Time values compare chronologically. A key with a
+// `Compare(T) int` or `Less(T) bool` method is compared with it when the
+// other key is assignable to T.
+//
+// Keys that fall outside these rules never panic: the last group is ordered by
+// type name and then by "%v" form, so the result is deterministic whatever the
+// input order, but carries no further meaning.
+func compareKeys(first, second interface{}) int {
+	a, b := reflect.ValueOf(first), reflect.ValueOf(second)
+	group := keyGroup(a)
+
+	if result := compareInts(int64(group), int64(keyGroup(b))); result != 0 || group == nilKeys {
+		return result
+	}
+
+	if result, ok := compareByMethod(first, second); ok {
+		return result
+	}
+
+	switch group {
+	case numberKeys:
+		return compareNumbers(a, b)
+	case stringKeys:
+		return strings.Compare(a.String(), b.String())
+	case boolKeys:
+		return compareBools(a.Bool(), b.Bool())
+	case timeKeys:
+		result, _ := compareTimes(first, second)
+		return result
+	}
+
+	if result := strings.Compare(a.Type().String(), b.Type().String()); result != 0 {
+		return result
+	}
+
+	return strings.Compare(fmt.Sprintf("%v", first), fmt.Sprintf("%v", second))
+}
+
+// The key groups rank sort keys of unrelated kinds against each other.
+const (
+	nilKeys = iota
+	numberKeys
+	stringKeys
+	boolKeys
+	timeKeys
+	otherKeys
+)
+
+func keyGroup(value reflect.Value) int {
+	switch {
+	case !value.IsValid():
+		return nilKeys
+	case isNumber(value):
+		return numberKeys
+	case value.Kind() == reflect.String:
+		return stringKeys
+	case value.Kind() == reflect.Bool:
+		return boolKeys
+	case value.Type() == reflect.TypeOf(time.Time{}):
+		return timeKeys
+	default:
+		return otherKeys
+	}
+}
+
+func compareTimes(first, second interface{}) (int, bool) {
+	a, ok := first.(time.Time)
+
+	if !ok {
+		return 0, false
+	}
+
+	b, ok := second.(time.Time)
+
+	if !ok {
+		return 0, false
+	}
+
+	switch {
+	case a.Before(b):
+		return -1, true
+	case a.After(b):
+		return 1, true
+	default:
+		return 0, true
+	}
+}
+
+func compareByMethod(first, second interface{}) (int, bool) {
+	a, b := reflect.ValueOf(first), reflect.ValueOf(second)
+
+	if method := a.MethodByName("Compare"); acceptsOne(method, b, reflect.Int) {
+		return sign(int(method.Call([]reflect.Value{b})[0].Int())), true
+	}
+
+	if method := a.MethodByName("Less"); acceptsOne(method, b, reflect.Bool) {
+		other := b.MethodByName("Less")
+
+		switch {
+		case method.Call([]reflect.Value{b})[0].Bool():
+			return -1, true
+		case acceptsOne(other, a, reflect.Bool) && other.Call([]reflect.Value{a})[0].Bool():
+			return 1, true
+		default:
+			return 0, true
+		}
+	}
+
+	return 0, false
+}
+
+func acceptsOne(method reflect.Value, argument reflect.Value, result reflect.Kind) bool {
+	if !method.IsValid() {
+		return false
+	}
+
+	signature := method.Type()
+
+	return signature.NumIn() == 1 && signature.NumOut() == 1 &&
+		argument.Type().AssignableTo(signature.In(0)) &&
+		signature.Out(0).Kind() == result
+}
+
+func isNumber(value reflect.Value) bool {
+	return isSigned(value) || isUnsigned(value) || isFloat(value)
+}
+
+func isSigned(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isUnsigned(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return false
+	}
+}
+
+func isFloat(value reflect.Value) bool {
+	return value.Kind() == reflect.Float32 || value.Kind() == reflect.Float64
+}
+
+func compareNumbers(a, b reflect.Value) int {
+	switch {
+	case isSigned(a) && isSigned(b):
+		return compareInts(a.Int(), b.Int())
+	case isUnsigned(a) && isUnsigned(b):
+		return compareUints(a.Uint(), b.Uint())
+	case isSigned(a) && isUnsigned(b):
+		if a.Int() < 0 {
+			return -1
+		}
+		return compareUints(uint64(a.Int()), b.Uint())
+	case isUnsigned(a) && isSigned(b):
+		return -compareNumbers(b, a)
+	}
+
+	return compareFloats(toFloat(a), toFloat(b))
+}
+
+func toFloat(value reflect.Value) float64 {
+	switch {
+	case isSigned(value):
+		return float64(value.Int())
+	case isUnsigned(value):
+		return float64(value.Uint())
+	default:
+		return value.Float()
+	}
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareUints(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareFloats(a, b float64) int {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return compareBools(!math.IsNaN(a), !math.IsNaN(b))
+	}
+
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareBools(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case b:
+		return -1
+	default:
+		return 1
+	}
+}
+
+func sign(value int) int {
+	switch {
+	case value < 0:
+		return -1
+	case value > 0:
+		return 1
+	default:
+		return 0
+	}
+}
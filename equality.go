@@ -0,0 +1,234 @@
+package filterable
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"math"
+	"reflect"
+)
+
+// EqualityComparer decides when two elements are the same for Distinct,
+// Union, Intersect and Except. Elements that are Equal must have the same Hash.
+type EqualityComparer interface {
+	Hash(value interface{}) uint64
+	Equal(a, b interface{}) bool
+}
+
+type equalityComparer struct {
+	hash  func(interface{}) uint64
+	equal func(a, b interface{}) bool
+}
+
+func (comparer equalityComparer) Hash(value interface{}) uint64 {
+	return comparer.hash(value)
+}
+
+func (comparer equalityComparer) Equal(a, b interface{}) bool {
+	return comparer.equal(a, b)
+}
+
+func NewEqualityComparer(hash func(interface{}) uint64, equal func(a, b interface{}) bool) EqualityComparer {
+	return equalityComparer{hash: hash, equal: equal}
+}
+
+// DeepEqual compares elements with reflect.DeepEqual, so slices, maps and
+// structs holding pointers can be used with the set operators.
+var DeepEqual EqualityComparer = NewEqualityComparer(deepHash, reflect.DeepEqual)
+
+// elementSet is a set of elements under an EqualityComparer. A nil comparer
+// uses Go equality, which panics on unhashable elements as a map would.
+type elementSet struct {
+	comparer EqualityComparer
+	plain    map[interface{}]bool
+	buckets  map[uint64][]interface{}
+}
+
+func newElementSet(comparer EqualityComparer) *elementSet {
+	if comparer == nil {
+		return &elementSet{plain: map[interface{}]bool{}}
+	}
+
+	return &elementSet{comparer: comparer, buckets: map[uint64][]interface{}{}}
+}
+
+// add inserts value and reports whether it was not already present.
+func (set *elementSet) add(value interface{}) bool {
+	if set.comparer == nil {
+		if set.plain[value] {
+			return false
+		}
+
+		set.plain[value] = true
+		return true
+	}
+
+	if set.contains(value) {
+		return false
+	}
+
+	hash := set.comparer.Hash(value)
+	set.buckets[hash] = append(set.buckets[hash], value)
+
+	return true
+}
+
+func (set *elementSet) contains(value interface{}) bool {
+	if set.comparer == nil {
+		return set.plain[value]
+	}
+
+	for _, candidate := range set.buckets[set.comparer.Hash(value)] {
+		if set.comparer.Equal(candidate, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// elementCounter is a multiset of elements under an EqualityComparer, with
+// the same nil comparer behaviour as elementSet.
+type elementCounter struct {
+	comparer EqualityComparer
+	plain    map[interface{}]int
+	buckets  map[uint64][]elementCount
+}
+
+type elementCount struct {
+	value interface{}
+	count int
+}
+
+func newElementCounter(comparer EqualityComparer) *elementCounter {
+	if comparer == nil {
+		return &elementCounter{plain: map[interface{}]int{}}
+	}
+
+	return &elementCounter{comparer: comparer, buckets: map[uint64][]elementCount{}}
+}
+
+func (counter *elementCounter) add(value interface{}) {
+	if counter.comparer == nil {
+		counter.plain[value]++
+		return
+	}
+
+	hash := counter.comparer.Hash(value)
+	bucket := counter.buckets[hash]
+
+	for index := range bucket {
+		if counter.comparer.Equal(bucket[index].value, value) {
+			bucket[index].count++
+			return
+		}
+	}
+
+	counter.buckets[hash] = append(bucket, elementCount{value: value, count: 1})
+}
+
+// take removes one copy of value and reports whether there was one to remove.
+func (counter *elementCounter) take(value interface{}) bool {
+	if counter.comparer == nil {
+		if counter.plain[value] == 0 {
+			return false
+		}
+
+		counter.plain[value]--
+		return true
+	}
+
+	bucket := counter.buckets[counter.comparer.Hash(value)]
+
+	for index := range bucket {
+		if bucket[index].count > 0 && counter.comparer.Equal(bucket[index].value, value) {
+			bucket[index].count--
+			return true
+		}
+	}
+
+	return false
+}
+
+// deepHashDepth bounds how far deepHash follows nested values. Deeper levels
+// only share a bucket, which keeps cyclic values safe without breaking the
+// rule that deeply equal values hash alike.
+const deepHashDepth = 8
+
+func deepHash(value interface{}) uint64 {
+	digest := fnv.New64a()
+	writeDeepHash(digest, reflect.ValueOf(value), deepHashDepth)
+
+	return digest.Sum64()
+}
+
+func writeDeepHash(digest hash.Hash64, value reflect.Value, depth int) {
+	if !value.IsValid() {
+		digest.Write([]byte{0})
+		return
+	}
+
+	digest.Write([]byte(value.Type().String()))
+
+	if depth == 0 {
+		return
+	}
+
+	buffer := make([]byte, 8)
+	writeUint := func(number uint64) {
+		binary.LittleEndian.PutUint64(buffer, number)
+		digest.Write(buffer)
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		if value.Bool() {
+			writeUint(1)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint(uint64(value.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeUint(floatBits(value.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		writeUint(floatBits(real(value.Complex())))
+		writeUint(floatBits(imag(value.Complex())))
+	case reflect.String:
+		digest.Write([]byte(value.String()))
+	case reflect.Array, reflect.Slice:
+		writeUint(uint64(value.Len()))
+
+		for idx := 0; idx < value.Len(); idx++ {
+			writeDeepHash(digest, value.Index(idx), depth-1)
+		}
+	case reflect.Map:
+		// entries are combined with a commutative sum so iteration order does not matter
+		sum := uint64(0)
+
+		for entries := value.MapRange(); entries.Next(); {
+			entry := fnv.New64a()
+			writeDeepHash(entry, entries.Key(), depth-1)
+			writeDeepHash(entry, entries.Value(), depth-1)
+			sum += entry.Sum64()
+		}
+
+		writeUint(uint64(value.Len()))
+		writeUint(sum)
+	case reflect.Struct:
+		for idx := 0; idx < value.NumField(); idx++ {
+			writeDeepHash(digest, value.Field(idx), depth-1)
+		}
+	case reflect.Ptr, reflect.Interface:
+		writeDeepHash(digest, value.Elem(), depth-1)
+	}
+}
+
+func floatBits(number float64) uint64 {
+	// -0 and +0 are equal and must hash alike
+	if number == 0 {
+		return 0
+	}
+
+	return math.Float64bits(number)
+}
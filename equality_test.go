@@ -0,0 +1,230 @@
+package filterable
+
+import (
+	"hash/fnv"
+	"strings"
+	"testing"
+)
+
+type account struct {
+	Name  string
+	Tags  []string
+	Owner *string
+}
+
+func Test_Filterable_EqualityComparers(t *testing.T) {
+	alice, alsoAlice := "alice", "alice"
+
+	caseInsensitive := NewEqualityComparer(func(value interface{}) uint64 {
+		digest := fnv.New64a()
+		digest.Write([]byte(strings.ToLower(value.(string))))
+		return digest.Sum64()
+	}, func(a, b interface{}) bool {
+		return strings.EqualFold(a.(string), b.(string))
+	})
+
+	scenarios := []testScenario{
+		{
+			name:     "when deduping unhashable slices",
+			input:    [][]int{{1, 2}, {3}, {1, 2}},
+			expected: format_any([][]int{{1, 2}, {3}}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.DistinctUsing(DeepEqual).Unwrap()), err
+			},
+		},
+		{
+			name:     "when deduping structs holding pointers",
+			input:    []account{{"a", []string{"x"}, &alice}, {"a", []string{"x"}, &alsoAlice}},
+			expected: format_any(1),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.DistinctUsing(DeepEqual).Count()), err
+			},
+		},
+		{
+			name:     "when deduping maps",
+			input:    []map[string]int{{"a": 1, "b": 2}, {"b": 2, "a": 1}, {"a": 2}},
+			expected: format_any(2),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.DistinctUsing(DeepEqual).Count()), err
+			},
+		},
+		{
+			name:     "when unioning with a custom comparer",
+			input:    []string{"Go", "Rust"},
+			expected: format_any([]string{"Go", "Rust", "ZIG"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				other, err := New([]string{"go", "ZIG", "zig"})
+				return format_any(collection.UnionUsing(other, caseInsensitive).Unwrap()), err
+			},
+		},
+		{
+			name:     "when intersecting unhashable slices",
+			input:    [][]int{{1}, {2}, {1}, {3}},
+			expected: format_any([][]int{{1}, {3}}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				other, err := New([][]int{{3}, {1}})
+				return format_any(collection.IntersectUsing(other, DeepEqual).Unwrap()), err
+			},
+		},
+		{
+			name:     "when excepting with a custom comparer",
+			input:    []string{"Go", "Rust", "rust", "C"},
+			expected: format_any([]string{"Rust", "C"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				other, err := New([]string{"GO"})
+				return format_any(collection.ExceptUsing(other, caseInsensitive).Unwrap()), err
+			},
+		},
+		{
+			name:     "when unioning does not modify the first collection",
+			input:    []int{1, 2, 3},
+			expected: format_any([]int{1, 2, 3}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				head := collection.Take(2)
+				head.Union(Range(9, 1))
+				return format_any(collection.Unwrap()), err
+			},
+		},
+	}
+
+	run_tests_on("EqualityComparers", scenarios, t)
+}
+
+func Test_Filterable_SetOperatorsBy(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string
+	}
+
+	users := []user{{1, "ann"}, {2, "ben"}, {3, "cat"}, {2, "ben again"}}
+	id := func(value interface{}) interface{} { return value.(user).ID }
+
+	scenarios := []testScenario{
+		{
+			name:     "when excluding users by banned ids",
+			input:    users,
+			expected: format_any([]user{{1, "ann"}, {3, "cat"}}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				banned, err := New([]int{2, 4})
+				return format_any(collection.ExceptBy(banned, id).Unwrap()), err
+			},
+		},
+		{
+			name:     "when excluding keeps one element per key",
+			input:    users,
+			expected: format_any([]user{{2, "ben"}, {3, "cat"}}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				banned, err := New([]int{1})
+				return format_any(collection.ExceptBy(banned, id).Unwrap()), err
+			},
+		},
+		{
+			name:     "when intersecting users by allowed ids",
+			input:    users,
+			expected: format_any([]user{{2, "ben"}, {3, "cat"}}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				allowed, err := New([]int{3, 2, 5})
+				return format_any(collection.IntersectBy(allowed, id).Unwrap()), err
+			},
+		},
+		{
+			name:     "when unioning users by id",
+			input:    users[:2],
+			expected: format_any([]user{{1, "ann"}, {2, "ben"}, {3, "cat"}}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				other, err := New(users[1:])
+				return format_any(collection.UnionBy(other, id).Unwrap()), err
+			},
+		},
+	}
+
+	run_tests_on("SetOperatorsBy", scenarios, t)
+}
+
+func Test_Filterable_MultisetOperators(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when intersecting with multiplicities",
+			input:    []string{"a", "a", "b"},
+			expected: format_any([]string{"a", "b"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				other, err := New([]string{"a", "b", "b"})
+				return format_any(collection.IntersectAll(other).Unwrap()), err
+			},
+		},
+		{
+			name:     "when intersecting keeps shared duplicates",
+			input:    []int{1, 1, 1, 2},
+			expected: format_any([]int{1, 1}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				other, err := New([]int{1, 3, 1})
+				return format_any(collection.IntersectAll(other).Unwrap()), err
+			},
+		},
+		{
+			name:     "when subtracting with multiplicities",
+			input:    []string{"a", "a", "b"},
+			expected: format_any([]string{"a", "b"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				other, err := New([]string{"a"})
+				return format_any(collection.ExceptAll(other).Unwrap()), err
+			},
+		},
+		{
+			name:     "when subtracting more copies than available",
+			input:    []string{"a", "b", "a"},
+			expected: format_any([]string{"b"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				other, err := New([]string{"a", "a", "a", "c"})
+				return format_any(collection.ExceptAll(other).Unwrap()), err
+			},
+		},
+		{
+			name:     "when intersecting slices deeply",
+			input:    [][]int{{1}, {1}, {2}},
+			expected: format_any([][]int{{1}, {2}}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				other, err := New([][]int{{2}, {1}, {3}})
+				return format_any(collection.IntersectAllUsing(other, DeepEqual).Unwrap()), err
+			},
+		},
+		{
+			name:     "when subtracting slices deeply",
+			input:    [][]int{{1}, {1}, {2}},
+			expected: format_any([][]int{{1}}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				other, err := New([][]int{{2}, {1}, {3}})
+				return format_any(collection.ExceptAllUsing(other, DeepEqual).Unwrap()), err
+			},
+		},
+		{
+			name:     "when unioning keeps duplicates",
+			input:    []int{1, 2, 2},
+			expected: format_any([]int{1, 2, 2, 2, 3}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				other, err := New([]int{2, 3})
+				return format_any(collection.UnionAll(other).Unwrap()), err
+			},
+		},
+	}
+
+	run_tests_on("MultisetOperators", scenarios, t)
+}
@@ -0,0 +1,16 @@
+package filterable
+
+import (
+	"errors"
+)
+
+var (
+	ErrNoElements   = errors.New("sequence contains no elements")
+	ErrMoreThanOne  = errors.New("sequence contains more than one matching element")
+	ErrOutOfRange   = errors.New("index out of range")
+	ErrNotNumeric   = errors.New("value is not numeric")
+	ErrOverflow     = errors.New("integer overflow")
+	ErrDuplicateKey = errors.New("duplicate key")
+	ErrUnknownField = errors.New("unknown field")
+	ErrInvalidOrder = errors.New("invalid ordering")
+)
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/karagulamos/filterable"
+)
+
+func main() {
+	words := filterable.From([]string{"go", "linq", "filterable", "query"})
+
+	lengths := filterable.Select(
+		words.Where(func(word string) bool {
+			return len(word) > 2
+		}),
+		func(word string) int {
+			return len(word)
+		},
+	).ToSlice()
+
+	fmt.Println(lengths)
+}
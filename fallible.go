@@ -0,0 +1,527 @@
+package filterable
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// fallibleIterator is an iterator whose stages can fail. Once it returns an
+// error the pipeline stops.
+type fallibleIterator func() (interface{}, bool, error)
+
+// Fallible is a deferred query whose predicates and selectors may return an
+// error. The first error halts the pipeline and is returned, as an
+// *ElementError, by the terminal operation.
+//
+// In safe mode (see Safe) a panic inside any callback is recovered and
+// reported the same way, wrapping a *PanicError. With a context (see
+// WithContext) every stage checks for cancellation before pulling the next
+// element, and the terminal operation returns ctx.Err().
+type Fallible struct {
+	iterate func(ctx context.Context) fallibleIterator
+	safe    bool
+	ctx     context.Context
+}
+
+// ElementError records the operator and the index of the element, within that
+// operator's input, at which a pipeline failed. Index is -1 when the failure
+// is not tied to one element, such as a comparison while sorting.
+type ElementError struct {
+	Op    string
+	Index int
+	Err   error
+}
+
+func (e *ElementError) Error() string {
+	return fmt.Sprintf("%s: element %d: %v", e.Op, e.Index, e.Err)
+}
+
+func (e *ElementError) Unwrap() error {
+	return e.Err
+}
+
+// PanicError is a panic recovered from a callback in safe mode. Element is the
+// value the callback was given and Value is what it panicked with.
+type PanicError struct {
+	Element interface{}
+	Value   interface{}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic on %T element: %v", e.Element, e.Value)
+}
+
+func (items *Filterable) AsFallible() *Fallible {
+	return items.AsLazy().AsFallible()
+}
+
+// Safe starts a Fallible query that turns panics in callbacks, such as a
+// failed type assertion on a heterogeneous element, into errors.
+func (items *Filterable) Safe() *Fallible {
+	return items.AsFallible().Safe()
+}
+
+func (items *Filterable) WithContext(ctx context.Context) *Fallible {
+	return items.AsFallible().WithContext(ctx)
+}
+
+func (items *Filterable) WhereE(predicate func(interface{}) (bool, error)) *Fallible {
+	return items.AsFallible().WhereE(predicate)
+}
+
+func (items *Filterable) SelectE(selector func(interface{}) (interface{}, error)) *Fallible {
+	return items.AsFallible().SelectE(selector)
+}
+
+func (query *Lazy) AsFallible() *Fallible {
+	iterate := query.iterate
+
+	return &Fallible{iterate: func(ctx context.Context) fallibleIterator {
+		next := iterate(ctx)
+
+		return checkContext(ctx, func() (interface{}, bool, error) {
+			item, ok := next()
+
+			// the Lazy stages end early rather than fail when ctx is done
+			if !ok {
+				return nil, false, ctx.Err()
+			}
+
+			return item, true, nil
+		})
+	}}
+}
+
+func (query *Lazy) Safe() *Fallible {
+	return query.AsFallible().Safe()
+}
+
+func (query *Lazy) WithContext(ctx context.Context) *Fallible {
+	return query.AsFallible().WithContext(ctx)
+}
+
+func (query *Lazy) WhereE(predicate func(interface{}) (bool, error)) *Fallible {
+	return query.AsFallible().WhereE(predicate)
+}
+
+func (query *Lazy) SelectE(selector func(interface{}) (interface{}, error)) *Fallible {
+	return query.AsFallible().SelectE(selector)
+}
+
+// Safe recovers panics in the callbacks of stages added after this call.
+func (query *Fallible) Safe() *Fallible {
+	return &Fallible{iterate: query.iterate, safe: true, ctx: query.ctx}
+}
+
+// WithContext makes the whole query, including the stages added before this
+// call, stop with ctx.Err() once ctx is done.
+func (query *Fallible) WithContext(ctx context.Context) *Fallible {
+	return &Fallible{iterate: query.iterate, safe: query.safe, ctx: ctx}
+}
+
+func (query *Fallible) stage(compose func(source fallibleIterator) fallibleIterator) *Fallible {
+	iterate := query.iterate
+
+	return &Fallible{iterate: func(ctx context.Context) fallibleIterator {
+		return compose(checkContext(ctx, iterate(ctx)))
+	}, safe: query.safe, ctx: query.ctx}
+}
+
+func (query *Fallible) start() fallibleIterator {
+	if query.ctx == nil {
+		return query.iterate(context.Background())
+	}
+
+	return query.iterate(query.ctx)
+}
+
+// checkContext makes source fail with ctx.Err() once ctx is done. Contexts
+// that can never be cancelled are not checked at all.
+func checkContext(ctx context.Context, source fallibleIterator) fallibleIterator {
+	if ctx.Done() == nil {
+		return source
+	}
+
+	return func() (interface{}, bool, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, false, err
+		}
+
+		return source()
+	}
+}
+
+// invoke runs a callback for the element at index, attributing any error, or
+// in safe mode any panic, to op and index.
+func (query *Fallible) invoke(op string, index int, item interface{}, callback func() error) (err error) {
+	if query.safe {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = &ElementError{Op: op, Index: index, Err: &PanicError{Element: item, Value: recovered}}
+			}
+		}()
+	}
+
+	if err := callback(); err != nil {
+		return &ElementError{Op: op, Index: index, Err: err}
+	}
+
+	return nil
+}
+
+func (query *Fallible) WhereE(predicate func(interface{}) (bool, error)) *Fallible {
+	return query.filter("WhereE", predicate)
+}
+
+func (query *Fallible) Where(predicate func(interface{}) bool) *Fallible {
+	return query.filter("Where", func(value interface{}) (bool, error) {
+		return predicate(value), nil
+	})
+}
+
+func (query *Fallible) SelectE(selector func(interface{}) (interface{}, error)) *Fallible {
+	return query.project("SelectE", selector)
+}
+
+func (query *Fallible) Select(selector func(interface{}) interface{}) *Fallible {
+	return query.project("Select", func(value interface{}) (interface{}, error) {
+		return selector(value), nil
+	})
+}
+
+func (query *Fallible) Skip(count int) *Fallible {
+	return query.stage(func(source fallibleIterator) fallibleIterator {
+		skipped := 0
+
+		return func() (interface{}, bool, error) {
+			for ; skipped < count; skipped++ {
+				if _, ok, err := source(); !ok || err != nil {
+					return nil, false, err
+				}
+			}
+
+			return source()
+		}
+	})
+}
+
+func (query *Fallible) Take(count int) *Fallible {
+	return query.stage(func(source fallibleIterator) fallibleIterator {
+		taken := 0
+
+		return func() (interface{}, bool, error) {
+			if taken >= count {
+				return nil, false, nil
+			}
+
+			taken++
+			return source()
+		}
+	})
+}
+
+func (query *Fallible) SkipWhile(predicate func(interface{}) bool) *Fallible {
+	return query.stage(func(source fallibleIterator) fallibleIterator {
+		index, skipping := 0, true
+
+		return func() (interface{}, bool, error) {
+			for {
+				item, ok, err := source()
+
+				if !ok || err != nil || !skipping {
+					return item, ok, err
+				}
+
+				if err := query.invoke("SkipWhile", index, item, func() error {
+					skipping = predicate(item)
+					return nil
+				}); err != nil {
+					return nil, false, err
+				}
+
+				index++
+
+				if !skipping {
+					return item, true, nil
+				}
+			}
+		}
+	})
+}
+
+func (query *Fallible) TakeWhile(predicate func(interface{}) bool) *Fallible {
+	return query.stage(func(source fallibleIterator) fallibleIterator {
+		index, taking := 0, true
+
+		return func() (interface{}, bool, error) {
+			if !taking {
+				return nil, false, nil
+			}
+
+			item, ok, err := source()
+
+			if !ok || err != nil {
+				return nil, false, err
+			}
+
+			if err := query.invoke("TakeWhile", index, item, func() error {
+				taking = predicate(item)
+				return nil
+			}); err != nil {
+				return nil, false, err
+			}
+
+			index++
+
+			if !taking {
+				return nil, false, nil
+			}
+
+			return item, true, nil
+		}
+	})
+}
+
+func (query *Fallible) OrderBy(selector func(object interface{}) interface{}) *Fallible {
+	return query.order("OrderBy", selector, false)
+}
+
+func (query *Fallible) OrderByDescending(selector func(object interface{}) interface{}) *Fallible {
+	return query.order("OrderByDescending", selector, true)
+}
+
+// order has to see every element before yielding the first one, so it drains
+// its source on the first pull and then serves the sorted elements.
+func (query *Fallible) order(op string, selector func(object interface{}) interface{}, descending bool) *Fallible {
+	return query.stage(func(source fallibleIterator) fallibleIterator {
+		var sorted Filterable
+		var failure error
+
+		drained := false
+
+		return func() (interface{}, bool, error) {
+			if !drained {
+				drained = true
+				sorted, failure = query.sort(op, source, selector, descending)
+			}
+
+			if failure != nil || len(sorted) == 0 {
+				return nil, false, failure
+			}
+
+			item := sorted[0]
+			sorted = sorted[1:]
+
+			return item, true, nil
+		}
+	})
+}
+
+func (query *Fallible) sort(op string, source fallibleIterator, selector func(object interface{}) interface{}, descending bool) (Filterable, error) {
+	items, keys := Filterable{}, Filterable{}
+
+	for {
+		item, ok, err := source()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			break
+		}
+
+		var key interface{}
+
+		if err := query.invoke(op, len(items), item, func() error {
+			key = selector(item)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+
+		items, keys = append(items, item), append(keys, key)
+	}
+
+	positions := make([]int, len(items))
+
+	for index := range positions {
+		positions[index] = index
+	}
+
+	// comparisons can call user Compare or Less methods, which are guarded too
+	if err := query.invoke(op, -1, nil, func() error {
+		sort.SliceStable(positions, func(i, j int) bool {
+			result := compareKeys(keys[positions[i]], keys[positions[j]])
+			return result != 0 && (result < 0) != descending
+		})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	sorted := make(Filterable, len(items))
+
+	for index, position := range positions {
+		sorted[index] = items[position]
+	}
+
+	return sorted, nil
+}
+
+func (query *Fallible) filter(op string, predicate func(interface{}) (bool, error)) *Fallible {
+	return query.stage(func(source fallibleIterator) fallibleIterator {
+		index := 0
+
+		return func() (interface{}, bool, error) {
+			for {
+				item, ok, err := source()
+
+				if !ok || err != nil {
+					return nil, false, err
+				}
+
+				index++
+
+				matched := false
+
+				if err := query.invoke(op, index-1, item, func() (err error) {
+					matched, err = predicate(item)
+					return err
+				}); err != nil {
+					return nil, false, err
+				}
+
+				if matched {
+					return item, true, nil
+				}
+			}
+		}
+	})
+}
+
+func (query *Fallible) project(op string, selector func(interface{}) (interface{}, error)) *Fallible {
+	return query.stage(func(source fallibleIterator) fallibleIterator {
+		index := 0
+
+		return func() (interface{}, bool, error) {
+			item, ok, err := source()
+
+			if !ok || err != nil {
+				return nil, false, err
+			}
+
+			index++
+
+			var key interface{}
+
+			if err := query.invoke(op, index-1, item, func() (err error) {
+				key, err = selector(item)
+				return err
+			}); err != nil {
+				return nil, false, err
+			}
+
+			return key, true, nil
+		}
+	})
+}
+
+func (query *Fallible) Unwrap() (Filterable, error) {
+	projection := Filterable{}
+
+	next := query.start()
+
+	for {
+		item, ok, err := next()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return projection, nil
+		}
+
+		projection = append(projection, item)
+	}
+}
+
+func (query *Fallible) AsFilterable() (*Filterable, error) {
+	projection, err := query.Unwrap()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &projection, nil
+}
+
+// First returns the first element, or ErrNoElements if there is none.
+func (query *Fallible) First() (interface{}, error) {
+	item, ok, err := query.start()()
+
+	if !ok && err == nil {
+		return nil, ErrNoElements
+	}
+
+	return item, err
+}
+
+func (query *Fallible) Count() (int, error) {
+	count := 0
+
+	next := query.start()
+
+	for {
+		_, ok, err := next()
+
+		if err != nil {
+			return 0, err
+		}
+
+		if !ok {
+			return count, nil
+		}
+
+		count++
+	}
+}
+
+func (query *Fallible) Any(predicate func(interface{}) bool) (bool, error) {
+	return query.find("Any", predicate)
+}
+
+func (query *Fallible) All(predicate func(interface{}) bool) (bool, error) {
+	found, err := query.find("All", func(value interface{}) bool {
+		return !predicate(value)
+	})
+
+	return !found && err == nil, err
+}
+
+func (query *Fallible) find(op string, predicate func(interface{}) bool) (bool, error) {
+	next := query.start()
+
+	for index := 0; ; index++ {
+		item, ok, err := next()
+
+		if !ok || err != nil {
+			return false, err
+		}
+
+		found := false
+
+		if err := query.invoke(op, index, item, func() error {
+			found = predicate(item)
+			return nil
+		}); err != nil {
+			return false, err
+		}
+
+		if found {
+			return true, nil
+		}
+	}
+}
@@ -0,0 +1,354 @@
+package filterable
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func parseInt(value interface{}) (interface{}, error) {
+	return strconv.Atoi(value.(string))
+}
+
+func Test_Fallible_SelectE(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when every selector call succeeds",
+			input:    []string{"1", "2", "3"},
+			expected: format_any([]int{1, 2, 3}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				result, err := collection.SelectE(parseInt).Unwrap()
+				return format_any(result), err
+			},
+		},
+		{
+			name:     "when a selector call fails",
+			input:    []string{"1", "x", "3"},
+			expected: format_any([]interface{}{"SelectE: element 1: strconv.Atoi: parsing \"x\": invalid syntax", true, 1}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				_, err := collection.SelectE(parseInt).Unwrap()
+				var elementErr *ElementError
+				found := errors.As(err, &elementErr)
+				return format_any([]interface{}{err, found && errors.Is(err, strconv.ErrSyntax), elementErr.Index}), nil
+			},
+		},
+		{
+			name:     "when the failing element is never reached",
+			input:    []string{"1", "2", "x"},
+			expected: format_any([]int{1, 2}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				result, err := collection.SelectE(parseInt).Take(2).Unwrap()
+				return format_any(result), err
+			},
+		},
+	}
+
+	run_tests_on("SelectE", scenarios, t)
+}
+
+func Test_Fallible_WhereE(t *testing.T) {
+	isEven := func(value interface{}) (bool, error) {
+		number, err := parseInt(value)
+		if err != nil {
+			return false, err
+		}
+		return number.(int)%2 == 0, nil
+	}
+
+	scenarios := []testScenario{
+		{
+			name:     "when every predicate call succeeds",
+			input:    []string{"1", "2", "4"},
+			expected: format_any([]string{"2", "4"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				result, err := collection.WhereE(isEven).Unwrap()
+				return format_any(result), err
+			},
+		},
+		{
+			name:     "when a predicate call fails after a later stage",
+			input:    []string{"1", "2", "?", "4"},
+			expected: format_any([]interface{}{0, "WhereE: element 2: strconv.Atoi: parsing \"?\": invalid syntax"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				count, err := collection.WhereE(isEven).Select(func(value interface{}) interface{} {
+					return value
+				}).Count()
+				return format_any([]interface{}{count, err}), nil
+			},
+		},
+		{
+			name:     "when chaining fallible stages on a lazy query",
+			input:    10_000_000,
+			expected: format_any([]int{6, 7}),
+			action: func(input interface{}) (string, error) {
+				result, err := LazyRange(1, input.(int)).
+					Select(func(value interface{}) interface{} {
+						return strconv.Itoa(value.(int))
+					}).
+					SelectE(parseInt).
+					Skip(5).
+					Take(2).
+					Unwrap()
+				return format_any(result), err
+			},
+		},
+	}
+
+	run_tests_on("WhereE", scenarios, t)
+}
+
+func Test_Fallible_Terminals(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when the pipeline succeeds",
+			input:    []string{"1", "2"},
+			expected: format_any([]interface{}{1, 2, true, false}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				query := collection.SelectE(parseInt)
+				first, _ := query.First()
+				count, _ := query.Count()
+				isPositive := func(value interface{}) bool { return value.(int) > 0 }
+				isOdd := func(value interface{}) bool { return value.(int)%2 == 1 }
+				all, _ := query.All(isPositive)
+				odd, err := query.All(isOdd)
+				return format_any([]interface{}{first, count, all, odd}), err
+			},
+		},
+		{
+			name:     "when the pipeline is empty",
+			input:    []string{"1", "2"},
+			expected: format_any([]interface{}{nil, ErrNoElements}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				first, err := collection.SelectE(parseInt).Where(func(value interface{}) bool {
+					return value.(int) > 2
+				}).First()
+				return format_any([]interface{}{first, err}), nil
+			},
+		},
+		{
+			name:     "when the pipeline fails",
+			input:    []string{"x"},
+			expected: format_any([]interface{}{nil, 0, false, false, true}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				query := collection.SelectE(parseInt)
+				first, _ := query.First()
+				count, _ := query.Count()
+				isPositive := func(value interface{}) bool { return value.(int) > 0 }
+				found, _ := query.Any(isPositive)
+				all, _ := query.All(isPositive)
+				result, err := query.AsFilterable()
+				return format_any([]interface{}{first, count, found, all, result == nil && err != nil}), nil
+			},
+		},
+	}
+
+	run_tests_on("Terminals", scenarios, t)
+}
+
+func Test_Fallible_Safe(t *testing.T) {
+	mixed := []interface{}{1, 2, "three", 4}
+	double := func(value interface{}) interface{} { return value.(int) * 2 }
+	isEven := func(value interface{}) bool { return value.(int)%2 == 0 }
+
+	scenarios := []testScenario{
+		{
+			name:     "when every callback succeeds",
+			input:    sliceInput,
+			expected: format_any([]int{4, 8, 12}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				result, err := collection.Safe().Where(isEven).Select(double).Unwrap()
+				return format_any(result), err
+			},
+		},
+		{
+			name:     "when a selector panics",
+			input:    mixed,
+			expected: format_any("Select: element 2: panic on string element: interface conversion: interface {} is string, not int"),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				_, err := collection.Safe().Select(double).Unwrap()
+				return format_any(err), nil
+			},
+		},
+		{
+			name:     "when the bad record is inspected",
+			input:    mixed,
+			expected: format_any([]interface{}{"Where", 2, "three"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				_, err := collection.Safe().Where(isEven).Count()
+				var elementErr *ElementError
+				var panicErr *PanicError
+				if !errors.As(err, &elementErr) || !errors.As(err, &panicErr) {
+					return format_any(err), nil
+				}
+				return format_any([]interface{}{elementErr.Op, elementErr.Index, panicErr.Element}), nil
+			},
+		},
+		{
+			name:     "when an ordering key selector panics",
+			input:    mixed,
+			expected: format_any("OrderBy: element 2: panic on string element: interface conversion: interface {} is string, not int"),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				_, err := collection.Safe().OrderBy(double).Unwrap()
+				return format_any(err), nil
+			},
+		},
+		{
+			name:     "when ordering succeeds",
+			input:    []int{3, 1, 2},
+			expected: format_any([]int{3, 2, 1}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				result, err := collection.Safe().OrderByDescending(identity).Unwrap()
+				return format_any(result), err
+			},
+		},
+		{
+			name:     "when a terminal predicate panics",
+			input:    mixed,
+			expected: format_any("All: element 2: panic on string element: interface conversion: interface {} is string, not int"),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				_, err := collection.Safe().All(func(value interface{}) bool { return value.(int) > 0 })
+				return format_any(err), nil
+			},
+		},
+		{
+			name:     "when partitioning by predicates",
+			input:    []interface{}{1, 3, 4, 5, "six"},
+			expected: format_any([]int{4}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				result, err := collection.Safe().SkipWhile(func(value interface{}) bool {
+					return value.(int)%2 == 1
+				}).TakeWhile(func(value interface{}) bool {
+					return value.(int) < 5
+				}).Unwrap()
+				return format_any(result), err
+			},
+		},
+		{
+			name:     "when a partitioning predicate panics",
+			input:    []interface{}{1, 3, 4, 5, "six"},
+			expected: format_any("TakeWhile: element 4: panic on string element: interface conversion: interface {} is string, not int"),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				_, err := collection.Safe().TakeWhile(func(value interface{}) bool {
+					return value.(int) < 9
+				}).Unwrap()
+				return format_any(err), nil
+			},
+		},
+		{
+			name:     "when safe mode is not enabled",
+			input:    mixed,
+			expected: format_any(true),
+			action: func(input interface{}) (panicked string, err error) {
+				collection, _ := New(input)
+				defer func() {
+					panicked = format_any(recover() != nil)
+				}()
+				collection.AsFallible().Select(double).Unwrap()
+				return
+			},
+		},
+	}
+
+	run_tests_on("Safe", scenarios, t)
+}
+
+func Test_Fallible_WithContext(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when the context is never cancelled",
+			input:    sliceInput,
+			expected: format_any([]int{2, 4, 6}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				result, err := collection.WithContext(context.Background()).Where(func(value interface{}) bool {
+					return value.(int)%2 == 0
+				}).Unwrap()
+				return format_any(result), err
+			},
+		},
+		{
+			name:     "when the context is already cancelled",
+			input:    sliceInput,
+			expected: format_any([]interface{}{nil, context.Canceled}),
+			action: func(input interface{}) (string, error) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				collection, _ := New(input)
+				first, err := collection.WithContext(ctx).First()
+				return format_any([]interface{}{first, err}), nil
+			},
+		},
+		{
+			name:     "when the context is cancelled part way",
+			input:    10_000_000,
+			expected: format_any([]interface{}{0, context.Canceled, 100}),
+			action: func(input interface{}) (string, error) {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				calls := 0
+				count, err := LazyRange(1, input.(int)).WithContext(ctx).Where(func(value interface{}) bool {
+					calls++
+					if calls == 100 {
+						cancel()
+					}
+					return true
+				}).Count()
+				return format_any([]interface{}{count, err, calls}), nil
+			},
+		},
+		{
+			name:     "when the context is attached after the filtering stage",
+			input:    10_000_000,
+			expected: format_any([]interface{}{context.DeadlineExceeded, true}),
+			action: func(input interface{}) (string, error) {
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+				defer cancel()
+				calls := 0
+				_, err := LazyRange(1, input.(int)).AsFallible().Where(func(value interface{}) bool {
+					calls++
+					time.Sleep(time.Microsecond)
+					return false
+				}).WithContext(ctx).Unwrap()
+				return format_any([]interface{}{err, calls < input.(int)}), nil
+			},
+		},
+		{
+			name:     "when the context is attached after a lazy filtering stage",
+			input:    10_000_000,
+			expected: format_any([]interface{}{0, context.Canceled, 100}),
+			action: func(input interface{}) (string, error) {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				calls := 0
+				count, err := LazyRange(1, input.(int)).Where(func(value interface{}) bool {
+					calls++
+					if calls == 100 {
+						cancel()
+					}
+					return false
+				}).WithContext(ctx).Count()
+				return format_any([]interface{}{count, err, calls}), nil
+			},
+		},
+	}
+
+	run_tests_on("WithContext", scenarios, t)
+}
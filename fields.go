@@ -0,0 +1,177 @@
+package filterable
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// FieldError reports a field path that cannot be read from an element.
+type FieldError struct {
+	Path string
+	Err  error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("field %q: %v", e.Path, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+type fieldKey struct {
+	owner reflect.Type
+	name  string
+}
+
+// fieldIndexes caches struct field lookups by owner type and field name.
+var fieldIndexes sync.Map
+
+// Field returns a selector that reads a dot-separated path of exported struct
+// fields and string map keys, such as "Address.City", following pointers and
+// interfaces on the way. A nil pointer or missing map key along the path
+// selects nil. A path that does not fit the element panics with a
+// *FieldError; use Pluck or OrderByField, or run the query in Safe mode, to
+// get it as an error instead.
+func Field(path string) func(interface{}) interface{} {
+	segments := strings.Split(path, ".")
+
+	return func(value interface{}) interface{} {
+		selected, err := readField(value, path, segments)
+
+		if err != nil {
+			panic(err)
+		}
+
+		return selected
+	}
+}
+
+// Pluck selects the value at a field path from every element.
+func (items *Filterable) Pluck(path string) (*Filterable, error) {
+	segments := strings.Split(path, ".")
+
+	projection := make(Filterable, len(*items))
+
+	for index, item := range *items {
+		value, err := readField(item, path, segments)
+
+		if err != nil {
+			return nil, err
+		}
+
+		projection[index] = value
+	}
+
+	return &projection, nil
+}
+
+// OrderByField sorts by the value at a field path, following a pointer field
+// to the value it points to. The path is checked against the type of every
+// element before sorting, the same way OrderBySpec checks it, so an unknown
+// field is reported even when every element is a nil pointer.
+func (items *Filterable) OrderByField(path string) (*Orderable, error) {
+	return newOrderableTerms(*items, nil, []orderTerm{fieldTerm(path, false)})
+}
+
+func (items *Filterable) OrderByFieldDescending(path string) (*Orderable, error) {
+	return newOrderableTerms(*items, nil, []orderTerm{fieldTerm(path, true)})
+}
+
+func (items *Orderable) ThenByField(path string) (*Orderable, error) {
+	return newOrderableTerms(items.source, items.keys, []orderTerm{fieldTerm(path, false)})
+}
+
+func (items *Orderable) ThenByFieldDescending(path string) (*Orderable, error) {
+	return newOrderableTerms(items.source, items.keys, []orderTerm{fieldTerm(path, true)})
+}
+
+func fieldTerm(path string, descending bool) orderTerm {
+	return orderTerm{path: path, key: orderKey{selector: orderField(path), descending: descending}}
+}
+
+func catchFieldError(order func() *Orderable) (ordered *Orderable, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			fieldErr, ok := recovered.(*FieldError)
+
+			if !ok {
+				panic(recovered)
+			}
+
+			ordered, err = nil, fieldErr
+		}
+	}()
+
+	return order(), nil
+}
+
+func readField(item interface{}, path string, segments []string) (interface{}, error) {
+	value := reflect.ValueOf(item)
+
+	for _, segment := range segments {
+		for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+			value = value.Elem()
+		}
+
+		if !value.IsValid() {
+			return nil, nil
+		}
+
+		switch value.Kind() {
+		case reflect.Struct:
+			index, err := fieldIndex(value.Type(), segment)
+
+			if err != nil {
+				return nil, &FieldError{Path: path, Err: err}
+			}
+
+			// embedded nil pointers along the index path select nil too
+			next, err := value.FieldByIndexErr(index)
+
+			if err != nil {
+				return nil, nil
+			}
+
+			value = next
+		case reflect.Map:
+			if value.Type().Key().Kind() != reflect.String {
+				return nil, &FieldError{Path: path, Err: fmt.Errorf("%w %q in %v", ErrUnknownField, segment, value.Type())}
+			}
+
+			value = value.MapIndex(reflect.ValueOf(segment).Convert(value.Type().Key()))
+		default:
+			return nil, &FieldError{Path: path, Err: fmt.Errorf("%w %q in %v", ErrUnknownField, segment, value.Type())}
+		}
+	}
+
+	for value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+
+	if !value.IsValid() {
+		return nil, nil
+	}
+
+	return value.Interface(), nil
+}
+
+func fieldIndex(owner reflect.Type, name string) ([]int, error) {
+	key := fieldKey{owner: owner, name: name}
+
+	if cached, ok := fieldIndexes.Load(key); ok {
+		return cached.([]int), nil
+	}
+
+	field, ok := owner.FieldByName(name)
+
+	if !ok || field.PkgPath != "" {
+		return nil, fmt.Errorf("%w %q in %v", ErrUnknownField, name, owner)
+	}
+
+	fieldIndexes.Store(key, field.Index)
+
+	return field.Index, nil
+}
@@ -0,0 +1,214 @@
+package filterable
+
+import (
+	"errors"
+	"testing"
+)
+
+type address struct {
+	City string
+	Zip  *string
+}
+
+type person struct {
+	Name    string
+	Age     int
+	Address *address
+	Tags    map[string]interface{}
+	secret  string
+}
+
+var (
+	zip    = "10115"
+	people = []person{
+		{Name: "Cleo", Age: 41, Address: &address{City: "Oslo"}, Tags: map[string]interface{}{"team": "red"}},
+		{Name: "Abe", Age: 29, Address: &address{City: "Berlin", Zip: &zip}},
+		{Name: "Bea", Age: 35},
+	}
+)
+
+func Test_Filterable_Field(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when reading a top-level field",
+			input:    people,
+			expected: format_any([]string{"Cleo", "Abe", "Bea"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				names, err := collection.Pluck("Name")
+				return format_any(names.Unwrap()), err
+			},
+		},
+		{
+			name:     "when reading through pointers",
+			input:    people,
+			expected: format_any([]interface{}{"Oslo", "Berlin", nil}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				cities, err := collection.Pluck("Address.City")
+				return format_any(cities.Unwrap()), err
+			},
+		},
+		{
+			name:     "when reading map keys",
+			input:    people,
+			expected: format_any([]interface{}{"red", nil, nil}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				teams, err := collection.Pluck("Tags.team")
+				return format_any(teams.Unwrap()), err
+			},
+		},
+		{
+			name:     "when selecting inside a query",
+			input:    people,
+			expected: format_any([]string{"Abe"}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				city := Field("Address.City")
+				young := collection.Where(func(value interface{}) bool {
+					return city(value) == "Berlin"
+				}).Select(Field("Name"))
+				return format_any(young.Unwrap()), err
+			},
+		},
+		{
+			name:     "when a field is unknown",
+			input:    people,
+			expected: format_any([]interface{}{`field "Address.Town": unknown field "Town" in filterable.address`, true}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				_, err := collection.Pluck("Address.Town")
+				return format_any([]interface{}{err, errors.Is(err, ErrUnknownField)}), nil
+			},
+		},
+		{
+			name:     "when a field is unexported",
+			input:    people,
+			expected: format_any(`field "secret": unknown field "secret" in filterable.person`),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				_, err := collection.Pluck("secret")
+				return format_any(err), nil
+			},
+		},
+		{
+			name:     "when a path goes past a scalar",
+			input:    people,
+			expected: format_any(`field "Age.Years": unknown field "Years" in int`),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				_, err := collection.Pluck("Age.Years")
+				return format_any(err), nil
+			},
+		},
+		{
+			name:     "when a selector fails in safe mode",
+			input:    people,
+			expected: format_any(`Select: element 0: panic on filterable.person element: field "Nmae": unknown field "Nmae" in filterable.person`),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				_, err := collection.Safe().Select(Field("Nmae")).Unwrap()
+				return format_any(err), nil
+			},
+		},
+	}
+
+	run_tests_on("Field", scenarios, t)
+}
+
+func Test_Filterable_OrderByField(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when ordering by a field",
+			input:    people,
+			expected: format_any([]interface{}{29, 35, 41}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				ordered, err := collection.OrderByField("Age")
+				if err != nil {
+					return "", err
+				}
+				ages, err := ordered.AsFilterable().Pluck("Age")
+				return format_any(ages.Unwrap()), err
+			},
+		},
+		{
+			name:     "when ordering by a nested field in descending",
+			input:    people,
+			expected: format_any([]string{"Cleo", "Abe", "Bea"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				ordered, err := collection.OrderByFieldDescending("Address.City")
+				if err != nil {
+					return "", err
+				}
+				names, err := ordered.AsFilterable().Pluck("Name")
+				return format_any(names.Unwrap()), err
+			},
+		},
+		{
+			name:     "when breaking ties by a field",
+			input:    employees,
+			expected: format_any([]int{35, 30, 22, 25, 40}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				ordered, err := collection.OrderByField("Department")
+				if err != nil {
+					return "", err
+				}
+				if ordered, err = ordered.ThenByField("LastName"); err != nil {
+					return "", err
+				}
+				if ordered, err = ordered.ThenByFieldDescending("Age"); err != nil {
+					return "", err
+				}
+				ages, err := ordered.AsFilterable().Pluck("Age")
+				return format_any(ages.Unwrap()), err
+			},
+		},
+		{
+			name:     "when ordering by a pointer field",
+			input:    []string{"z", "a", "m"},
+			expected: format_any([]string{"a", "m", "z"}),
+			action: func(input interface{}) (string, error) {
+				type named struct{ Name *string }
+				collection := Filterable{}
+				for _, name := range input.([]string) {
+					name := name
+					collection = append(collection, named{Name: &name})
+				}
+				ordered, err := collection.OrderByField("Name")
+				if err != nil {
+					return "", err
+				}
+				names := ordered.AsFilterable().Select(func(value interface{}) interface{} {
+					return *value.(named).Name
+				})
+				return format_any(names.Unwrap()), nil
+			},
+		},
+		{
+			name:     "when no element reaches an unknown field",
+			input:    []*person{nil, nil},
+			expected: format_any(`field "Nope": unknown field "Nope" in filterable.person`),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				_, err := collection.OrderByFieldDescending("Nope")
+				return format_any(err), nil
+			},
+		},
+		{
+			name:     "when ordering by an unknown field",
+			input:    people,
+			expected: format_any([]interface{}{nil, `field "Height": unknown field "Height" in filterable.person`}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				ordered, err := collection.OrderByField("Height")
+				return format_any([]interface{}{ordered, err}), nil
+			},
+		},
+	}
+
+	run_tests_on("OrderByField", scenarios, t)
+}
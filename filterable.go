@@ -3,12 +3,20 @@ package filterable
 import (
 	"fmt"
 	"reflect"
-	"sort"
-	"strings"
 )
 
 type Filterable []interface{}
-type orderable Filterable
+
+// KeyValue is a map entry, as produced by New for maps.
+type KeyValue struct {
+	Key   interface{}
+	Value interface{}
+}
+
+// Iterator is a source of elements that New and FromIterator can read.
+type Iterator interface {
+	Next() (interface{}, bool)
+}
 
 type emptyFilterableSelection struct{}
 
@@ -16,22 +24,63 @@ var (
 	empty = &emptyFilterableSelection{}
 )
 
-func New(slice interface{}) (*Filterable, error) {
-	s := reflect.ValueOf(slice)
-
-	if s.Kind() != reflect.Slice && s.Kind() != reflect.Array {
-		return nil, fmt.Errorf("argument not a valid slice")
+// New copies a sequence into a Filterable. It accepts a slice or array, a map
+// (as KeyValue pairs ordered by key, compared like OrderBy keys), a string (as
+// runes), a receive channel (read until it is closed) or an Iterator (read
+// until it is exhausted).
+func New(source interface{}) (*Filterable, error) {
+	if iterator, ok := source.(Iterator); ok {
+		return FromIterator(iterator).AsFilterable(), nil
 	}
 
-	size := s.Len()
+	s := reflect.ValueOf(source)
 
-	filterable := make(Filterable, size)
+	switch s.Kind() {
+	case reflect.Slice, reflect.Array:
+		size := s.Len()
 
-	for idx := 0; idx < size; idx++ {
-		filterable[idx] = s.Index(idx).Interface()
+		filterable := make(Filterable, size)
+
+		for idx := 0; idx < size; idx++ {
+			filterable[idx] = s.Index(idx).Interface()
+		}
+
+		return &filterable, nil
+	case reflect.Map:
+		pairs := make(Filterable, 0, s.Len())
+
+		for entries := s.MapRange(); entries.Next(); {
+			pairs = append(pairs, KeyValue{Key: entries.Key().Interface(), Value: entries.Value().Interface()})
+		}
+
+		// keys that compare equal, such as 1 and uint8(1), are told apart by
+		// type and form so map iteration order never shows through
+		return pairs.OrderBy(func(pair interface{}) interface{} {
+			return pair.(KeyValue).Key
+		}).ThenBy(func(pair interface{}) interface{} {
+			return fmt.Sprintf("%T", pair.(KeyValue).Key)
+		}).ThenBy(func(pair interface{}) interface{} {
+			return fmt.Sprintf("%v", pair.(KeyValue).Key)
+		}).AsFilterable(), nil
+	case reflect.String:
+		filterable := Filterable{}
+
+		for _, character := range s.String() {
+			filterable = append(filterable, character)
+		}
+
+		return &filterable, nil
+	case reflect.Chan:
+		query, err := FromChannel(source)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return query.AsFilterable(), nil
 	}
 
-	return &filterable, nil
+	return nil, fmt.Errorf("argument not a valid sequence")
 }
 
 func Empty() *emptyFilterableSelection {
@@ -58,19 +107,44 @@ func (items *Filterable) Unwrap() Filterable {
 	return *items
 }
 
-func (items *orderable) Unwrap() orderable {
-	return *items
-}
+// UnwrapInto replaces the slice that target points to with the elements,
+// which must be assignable to its element type. It is the inverse of New.
+func (items *Filterable) UnwrapInto(target interface{}) error {
+	pointer := reflect.ValueOf(target)
 
-func (items *orderable) AsFilterable() *Filterable {
-	return (*Filterable)(items)
+	if pointer.Kind() != reflect.Ptr || pointer.IsNil() || pointer.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("argument not a valid slice pointer")
+	}
+
+	slice, err := items.ToSliceOf(pointer.Elem().Type().Elem())
+
+	if err != nil {
+		return err
+	}
+
+	pointer.Elem().Set(reflect.ValueOf(slice))
+	return nil
 }
 
-func (items *Filterable) AsOrderable() *orderable {
-	orderable := orderable{}
+// ToSliceOf returns the elements as a []elementType wrapped in an interface{}.
+func (items *Filterable) ToSliceOf(elementType reflect.Type) (interface{}, error) {
+	if elementType == nil {
+		return nil, fmt.Errorf("argument not a valid element type")
+	}
 
-	orderable = append(orderable, *items...)
-	return &orderable
+	slice := reflect.MakeSlice(reflect.SliceOf(elementType), len(*items), len(*items))
+
+	for index, item := range *items {
+		value, ok := assignable(item, elementType)
+
+		if !ok {
+			return nil, fmt.Errorf("element %d is %T, not %v", index, item, elementType)
+		}
+
+		slice.Index(index).Set(value)
+	}
+
+	return slice.Interface(), nil
 }
 
 func (items *Filterable) Where(predicate func(interface{}) bool) *Filterable {
@@ -125,10 +199,78 @@ func (items *Filterable) SelectIndexed(keySelector func(int, interface{}) interf
 	return &projection
 }
 
+func (items *Filterable) SelectMany(selector func(interface{}) *Filterable) *Filterable {
+	projection := Filterable{}
+
+	for _, item := range *items {
+		if children := selector(item); children != nil {
+			projection = append(projection, *children...)
+		}
+	}
+
+	return &projection
+}
+
+func (items *Filterable) Zip(collection *Filterable, resultSelector func(interface{}, interface{}) interface{}) *Filterable {
+	size := len(*items)
+
+	if len(*collection) < size {
+		size = len(*collection)
+	}
+
+	projection := make(Filterable, size)
+
+	for idx := 0; idx < size; idx++ {
+		projection[idx] = resultSelector((*items)[idx], (*collection)[idx])
+	}
+
+	return &projection
+}
+
+// ZipLongest pairs elements until both sequences are exhausted, filling in
+// for the shorter one with firstFill or secondFill.
+func (items *Filterable) ZipLongest(collection *Filterable, firstFill interface{}, secondFill interface{}, resultSelector func(interface{}, interface{}) interface{}) *Filterable {
+	size := len(*items)
+
+	if len(*collection) > size {
+		size = len(*collection)
+	}
+
+	projection := make(Filterable, size)
+
+	for idx := 0; idx < size; idx++ {
+		first, second := firstFill, secondFill
+
+		if idx < len(*items) {
+			first = (*items)[idx]
+		}
+
+		if idx < len(*collection) {
+			second = (*collection)[idx]
+		}
+
+		projection[idx] = resultSelector(first, second)
+	}
+
+	return &projection
+}
+
 func (items *Filterable) Distinct() *Filterable {
-	return items.DistinctBy(func(value interface{}) interface{} {
-		return value
-	})
+	return items.DistinctUsing(nil)
+}
+
+func (items *Filterable) DistinctUsing(comparer EqualityComparer) *Filterable {
+	set := newElementSet(comparer)
+
+	deduped := Filterable{}
+
+	for _, item := range *items {
+		if set.add(item) {
+			deduped = append(deduped, item)
+		}
+	}
+
+	return &deduped
 }
 
 func (items *Filterable) DistinctBy(keySelector func(interface{}) interface{}) *Filterable {
@@ -149,44 +291,165 @@ func (items *Filterable) DistinctBy(keySelector func(interface{}) interface{}) *
 }
 
 func (items *Filterable) Union(collection *Filterable) *Filterable {
-	projection := append(*items, *collection...)
-	return (&projection).Distinct()
+	return items.UnionUsing(collection, nil)
+}
+
+func (items *Filterable) UnionUsing(collection *Filterable, comparer EqualityComparer) *Filterable {
+	projection := make(Filterable, 0, len(*items)+len(*collection))
+	projection = append(append(projection, *items...), *collection...)
+
+	return (&projection).DistinctUsing(comparer)
 }
 
 func (items *Filterable) Intersect(collection *Filterable) *Filterable {
-	second := map[interface{}]bool{}
+	return items.IntersectUsing(collection, nil)
+}
+
+func (items *Filterable) IntersectUsing(collection *Filterable, comparer EqualityComparer) *Filterable {
+	second := newElementSet(comparer)
 
 	for _, item := range *collection {
-		second[item] = true
+		second.add(item)
 	}
 
 	intersection := Filterable{}
 
 	for _, item := range *items {
-		if _, exist := second[item]; exist {
+		if second.contains(item) {
 			intersection = append(intersection, item)
 		}
 	}
 
-	return (&intersection).Distinct()
+	return (&intersection).DistinctUsing(comparer)
 }
 
 func (items *Filterable) Except(collection *Filterable) *Filterable {
-	second := map[interface{}]bool{}
+	return items.ExceptUsing(collection, nil)
+}
+
+func (items *Filterable) ExceptUsing(collection *Filterable, comparer EqualityComparer) *Filterable {
+	second := newElementSet(comparer)
 
 	for _, item := range *collection {
-		second[item] = true
+		second.add(item)
 	}
 
 	projection := Filterable{}
 
 	for _, item := range *items {
-		if _, exist := second[item]; !exist {
+		if !second.contains(item) {
 			projection = append(projection, item)
 		}
 	}
 
-	return (&projection).Distinct()
+	return (&projection).DistinctUsing(comparer)
+}
+
+// UnionBy concatenates both collections and keeps the first element seen for
+// each key.
+func (items *Filterable) UnionBy(collection *Filterable, keySelector func(interface{}) interface{}) *Filterable {
+	projection := make(Filterable, 0, len(*items)+len(*collection))
+	projection = append(append(projection, *items...), *collection...)
+
+	return (&projection).DistinctBy(keySelector)
+}
+
+// IntersectBy keeps the elements whose key appears in keys, one per key.
+func (items *Filterable) IntersectBy(keys *Filterable, keySelector func(interface{}) interface{}) *Filterable {
+	second := newElementSet(nil)
+
+	for _, key := range *keys {
+		second.add(key)
+	}
+
+	seen := newElementSet(nil)
+
+	intersection := Filterable{}
+
+	for _, item := range *items {
+		if key := keySelector(item); second.contains(key) && seen.add(key) {
+			intersection = append(intersection, item)
+		}
+	}
+
+	return &intersection
+}
+
+// ExceptBy keeps the elements whose key does not appear in keys, one per key.
+func (items *Filterable) ExceptBy(keys *Filterable, keySelector func(interface{}) interface{}) *Filterable {
+	seen := newElementSet(nil)
+
+	for _, key := range *keys {
+		seen.add(key)
+	}
+
+	projection := Filterable{}
+
+	for _, item := range *items {
+		if seen.add(keySelector(item)) {
+			projection = append(projection, item)
+		}
+	}
+
+	return &projection
+}
+
+// UnionAll concatenates both collections, keeping duplicates.
+func (items *Filterable) UnionAll(collection *Filterable) *Filterable {
+	projection := make(Filterable, 0, len(*items)+len(*collection))
+	projection = append(append(projection, *items...), *collection...)
+
+	return &projection
+}
+
+// IntersectAll is a multiset intersection: an element appears as many times
+// as it does in the collection with fewer copies of it.
+func (items *Filterable) IntersectAll(collection *Filterable) *Filterable {
+	return items.IntersectAllUsing(collection, nil)
+}
+
+func (items *Filterable) IntersectAllUsing(collection *Filterable, comparer EqualityComparer) *Filterable {
+	remaining := countElements(collection, comparer)
+
+	intersection := Filterable{}
+
+	for _, item := range *items {
+		if remaining.take(item) {
+			intersection = append(intersection, item)
+		}
+	}
+
+	return &intersection
+}
+
+// ExceptAll is a multiset difference: each element of collection removes one
+// matching element, earliest first.
+func (items *Filterable) ExceptAll(collection *Filterable) *Filterable {
+	return items.ExceptAllUsing(collection, nil)
+}
+
+func (items *Filterable) ExceptAllUsing(collection *Filterable, comparer EqualityComparer) *Filterable {
+	remaining := countElements(collection, comparer)
+
+	projection := Filterable{}
+
+	for _, item := range *items {
+		if !remaining.take(item) {
+			projection = append(projection, item)
+		}
+	}
+
+	return &projection
+}
+
+func countElements(items *Filterable, comparer EqualityComparer) *elementCounter {
+	counts := newElementCounter(comparer)
+
+	for _, item := range *items {
+		counts.add(item)
+	}
+
+	return counts
 }
 
 func (items *Filterable) Skip(count int) *Filterable {
@@ -280,55 +543,115 @@ func (items *Filterable) LastWhere(predicate func(interface{}) bool) interface{}
 	return nil
 }
 
-func (items *Filterable) Count() int {
-	return len(*items)
+// TryFirst is First that tells "no element" apart from a nil element.
+func (items *Filterable) TryFirst() (interface{}, bool) {
+	return items.TryFirstWhere(func(interface{}) bool {
+		return true
+	})
 }
 
-func (items *Filterable) CountWhere(predicate func(interface{}) bool) int {
-	count := 0
+func (items *Filterable) TryFirstWhere(predicate func(interface{}) bool) (interface{}, bool) {
+	for _, item := range *items {
+		if predicate(item) {
+			return item, true
+		}
+	}
 
-	for items, idx, size := *items, 0, len(*items); idx < size; idx++ {
+	return nil, false
+}
+
+func (items *Filterable) FirstOrDefault(defaultValue interface{}) interface{} {
+	if item, ok := items.TryFirst(); ok {
+		return item
+	}
+
+	return defaultValue
+}
+
+// TryLast is Last that tells "no element" apart from a nil element.
+func (items *Filterable) TryLast() (interface{}, bool) {
+	return items.TryLastWhere(func(interface{}) bool {
+		return true
+	})
+}
+
+func (items *Filterable) TryLastWhere(predicate func(interface{}) bool) (interface{}, bool) {
+	for items, idx := *items, len(*items)-1; idx >= 0; idx-- {
 		if predicate(items[idx]) {
-			count++
+			return items[idx], true
 		}
 	}
 
-	return count
+	return nil, false
 }
 
-func (items *Filterable) OrderBy(selector func(object interface{}) interface{}) *orderable {
-	values := *items.AsOrderable()
+func (items *Filterable) LastOrDefault(defaultValue interface{}) interface{} {
+	if item, ok := items.TryLast(); ok {
+		return item
+	}
 
-	sort.SliceStable(values, func(i, j int) bool {
-		first := fmt.Sprintf("%v", selector(values[i]))
-		second := fmt.Sprintf("%v", selector(values[j]))
+	return defaultValue
+}
 
-		return first < second
+// Single returns the only element, or ErrNoElements or ErrMoreThanOne.
+func (items *Filterable) Single() (interface{}, error) {
+	return items.SingleWhere(func(interface{}) bool {
+		return true
 	})
+}
+
+func (items *Filterable) SingleWhere(predicate func(interface{}) bool) (interface{}, error) {
+	var single interface{}
+
+	found := false
+
+	for _, item := range *items {
+		if !predicate(item) {
+			continue
+		}
+
+		if found {
+			return nil, ErrMoreThanOne
+		}
 
-	return &values
+		single, found = item, true
+	}
+
+	if !found {
+		return nil, ErrNoElements
+	}
+
+	return single, nil
 }
 
-func (items *Filterable) OrderByDescending(selector func(object interface{}) interface{}) *orderable {
-	values := *items.AsOrderable()
+func (items *Filterable) ElementAt(index int) (interface{}, error) {
+	if index < 0 || index >= len(*items) {
+		return nil, fmt.Errorf("%w: index %d, length %d", ErrOutOfRange, index, len(*items))
+	}
 
-	sort.SliceStable(values, func(i, j int) bool {
-		first := fmt.Sprintf("%v", selector(values[i]))
-		second := fmt.Sprintf("%v", selector(values[j]))
+	return (*items)[index], nil
+}
 
-		return first > second
-	})
+func (items *Filterable) ElementAtOrDefault(index int, defaultValue interface{}) interface{} {
+	if item, err := items.ElementAt(index); err == nil {
+		return item
+	}
 
-	return &values
+	return defaultValue
 }
 
-func (items *Filterable) Order(sortOrder string, selector func(object interface{}) interface{}) *orderable {
-	switch strings.ToLower(sortOrder) {
-	case "asc":
-		return items.OrderBy(selector)
-	case "desc":
-		return items.OrderByDescending(selector)
-	default:
-		return (*orderable)(items)
+func (items *Filterable) Count() int {
+	return len(*items)
+}
+
+func (items *Filterable) CountWhere(predicate func(interface{}) bool) int {
+	count := 0
+
+	for items, idx, size := *items, 0, len(*items); idx < size; idx++ {
+		if predicate(items[idx]) {
+			count++
+		}
 	}
+
+	return count
 }
@@ -23,7 +23,7 @@ var (
 	skipCount = 1
 	takeCount = 1
 
-	errInvalid = fmt.Errorf("argument not a valid slice")
+	errInvalid = fmt.Errorf("argument not a valid sequence")
 )
 
 func Test_Filterable_New(t *testing.T) {
@@ -1464,3 +1464,432 @@ func run_tests_on(method string, scenarios []testScenario, t *testing.T) {
 		})
 	}
 }
+
+func Test_Filterable_SelectMany(t *testing.T) {
+	expand := func(value interface{}) *Filterable {
+		return Range(0, value.(int))
+	}
+
+	scenarios := []testScenario{
+		{
+			name:     "when an empty slice is given",
+			input:    emptyInput,
+			expected: format_any([]int{}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.SelectMany(expand).Unwrap()), err
+			},
+		},
+		{
+			name:     "when flattening one level",
+			input:    []int{2, 0, 3},
+			expected: format_any([]int{0, 1, 0, 1, 2}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.SelectMany(expand).Unwrap()), err
+			},
+		},
+		{
+			name:     "when the selector returns nil",
+			input:    []int{1, 2},
+			expected: format_any([]int{0, 1}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				result := collection.SelectMany(func(value interface{}) *Filterable {
+					if value.(int) == 1 {
+						return nil
+					}
+					return expand(value)
+				})
+				return format_any(result.Unwrap()), err
+			},
+		},
+		{
+			name:     "when flattening a lazy query",
+			input:    10_000_000,
+			expected: format_any([]int{0, 0, 1, 0}),
+			action: func(input interface{}) (string, error) {
+				return format_any(LazyRange(1, input.(int)).SelectMany(expand).Take(4).Unwrap()), nil
+			},
+		},
+	}
+
+	run_tests_on("SelectMany", scenarios, t)
+}
+
+func Test_Filterable_Zip(t *testing.T) {
+	pair := func(first interface{}, second interface{}) interface{} {
+		return format_any(first) + format_any(second)
+	}
+
+	scenarios := []testScenario{
+		{
+			name:     "when the second collection is shorter",
+			input:    []int{1, 2, 3},
+			expected: format_any([]string{"1a", "2b"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				letters, err := New([]string{"a", "b"})
+				return format_any(collection.Zip(letters, pair).Unwrap()), err
+			},
+		},
+		{
+			name:     "when the first collection is shorter",
+			input:    []int{1},
+			expected: format_any([]string{"1a"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				letters, err := New([]string{"a", "b"})
+				return format_any(collection.Zip(letters, pair).Unwrap()), err
+			},
+		},
+		{
+			name:     "when zipping to the longest collection",
+			input:    []int{1, 2, 3},
+			expected: format_any([]string{"1a", "2?", "3?"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				letters, err := New([]string{"a"})
+				return format_any(collection.ZipLongest(letters, 0, "?", pair).Unwrap()), err
+			},
+		},
+		{
+			name:     "when zipping to the longest with a longer second collection",
+			input:    []int{1},
+			expected: format_any([]string{"1a", "0b"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				letters, err := New([]string{"a", "b"})
+				return format_any(collection.ZipLongest(letters, 0, "?", pair).Unwrap()), err
+			},
+		},
+	}
+
+	run_tests_on("Zip", scenarios, t)
+}
+
+func Test_Filterable_ElementPresence(t *testing.T) {
+	isEven := func(value interface{}) bool {
+		return value != nil && value.(int)%2 == 0
+	}
+
+	scenarios := []testScenario{
+		{
+			name:     "when an empty slice is given",
+			input:    emptyInput,
+			expected: format_any([]interface{}{nil, false, nil, false, -1, -2}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				first, hasFirst := collection.TryFirst()
+				last, hasLast := collection.TryLast()
+				return format_any([]interface{}{first, hasFirst, last, hasLast, collection.FirstOrDefault(-1), collection.LastOrDefault(-2)}), err
+			},
+		},
+		{
+			name:     "when the first and last elements are nil",
+			input:    []interface{}{nil, 2, nil},
+			expected: format_any([]interface{}{nil, true, nil, true, nil, nil}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				first, hasFirst := collection.TryFirst()
+				last, hasLast := collection.TryLast()
+				return format_any([]interface{}{first, hasFirst, last, hasLast, collection.FirstOrDefault(-1), collection.LastOrDefault(-2)}), err
+			},
+		},
+		{
+			name:     "when matching by predicate",
+			input:    sliceInput,
+			expected: format_any([]interface{}{2, true, 6, true}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				first, hasFirst := collection.TryFirstWhere(isEven)
+				last, hasLast := collection.TryLastWhere(isEven)
+				return format_any([]interface{}{first, hasFirst, last, hasLast}), err
+			},
+		},
+	}
+
+	run_tests_on("ElementPresence", scenarios, t)
+}
+
+func Test_Filterable_Single(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when an empty slice is given",
+			input:    emptyInput,
+			expected: format_any(ErrNoElements),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				_, err := collection.Single()
+				return format_any(err), nil
+			},
+		},
+		{
+			name:     "when there is exactly one element",
+			input:    []interface{}{nil},
+			expected: format_any(nil),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				single, err := collection.Single()
+				return format_any(single), err
+			},
+		},
+		{
+			name:     "when more than one element matches",
+			input:    sliceInput,
+			expected: format_any(ErrMoreThanOne),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				_, err := collection.SingleWhere(func(value interface{}) bool {
+					return value.(int) > 5
+				})
+				return format_any(err), nil
+			},
+		},
+		{
+			name:     "when exactly one element matches",
+			input:    sliceInput,
+			expected: format_any(7),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				single, err := collection.SingleWhere(func(value interface{}) bool {
+					return value.(int) > 6
+				})
+				return format_any(single), err
+			},
+		},
+	}
+
+	run_tests_on("Single", scenarios, t)
+}
+
+func Test_Filterable_ElementAt(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when the index is in range",
+			input:    sliceInput,
+			expected: format_any(3),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				element, err := collection.ElementAt(2)
+				return format_any(element), err
+			},
+		},
+		{
+			name:     "when the index is out of range",
+			input:    sliceInput,
+			expected: format_any("index out of range: index 7, length 7"),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				_, err := collection.ElementAt(7)
+				return format_any(err), nil
+			},
+		},
+		{
+			name:     "when a default is given",
+			input:    sliceInput,
+			expected: format_any([]int{1, 0}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any([]interface{}{collection.ElementAtOrDefault(0, 0), collection.ElementAtOrDefault(-1, 0)}), err
+			},
+		},
+	}
+
+	run_tests_on("ElementAt", scenarios, t)
+}
+
+type countdown struct {
+	remaining int
+}
+
+func (c *countdown) Next() (interface{}, bool) {
+	if c.remaining <= 0 {
+		return nil, false
+	}
+
+	c.remaining--
+	return c.remaining + 1, true
+}
+
+func Test_Filterable_NewSources(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when a map is given",
+			input:    map[string]int{"b": 2, "c": 3, "a": 1},
+			expected: format_any([]KeyValue{{"a", 1}, {"b", 2}, {"c", 3}}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.Unwrap()), err
+			},
+		},
+		{
+			name:     "when a map with numeric keys is given",
+			input:    map[int]string{10: "ten", 9: "nine", -1: "minus one"},
+			expected: format_any([]KeyValue{{-1, "minus one"}, {9, "nine"}, {10, "ten"}}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.Unwrap()), err
+			},
+		},
+		{
+			name:     "when a map with mixed key types is given",
+			input:    map[interface{}]int{5: 0, "a": 1, uint8(1): 2, 1: 3, nil: 4},
+			expected: format_any([]KeyValue{{nil, 4}, {1, 3}, {uint8(1), 2}, {5, 0}, {"a", 1}}),
+			action: func(input interface{}) (string, error) {
+				orders := map[string]bool{}
+				for run := 0; run < 50; run++ {
+					collection, err := New(input)
+					if err != nil {
+						return "", err
+					}
+					orders[format_any(collection.Unwrap())] = true
+				}
+				if len(orders) != 1 {
+					return format_any(orders), nil
+				}
+				collection, err := New(input)
+				return format_any(collection.Unwrap()), err
+			},
+		},
+		{
+			name:     "when a string is given",
+			input:    "héllo",
+			expected: format_any([]rune{'h', 'é', 'l', 'l', 'o'}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.Unwrap()), err
+			},
+		},
+		{
+			name:     "when a closed channel is given",
+			input:    make(chan int, 3),
+			expected: format_any([]int{1, 2}),
+			action: func(input interface{}) (string, error) {
+				ch := input.(chan int)
+				ch <- 1
+				ch <- 2
+				close(ch)
+				collection, err := New(ch)
+				return format_any(collection.Unwrap()), err
+			},
+		},
+		{
+			name:     "when an iterator is given",
+			input:    &countdown{3},
+			expected: format_any([]int{3, 2, 1}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.Unwrap()), err
+			},
+		},
+		{
+			name:     "when an iterator is queried lazily",
+			input:    &countdown{1_000_000},
+			expected: format_any([]interface{}{[]int{1_000_000, 999_999}, 999_998}),
+			action: func(input interface{}) (string, error) {
+				source := input.(*countdown)
+				taken := FromIterator(source).Take(2).Unwrap()
+				return format_any([]interface{}{taken, source.remaining}), nil
+			},
+		},
+		{
+			name:     "when an unsupported value is given",
+			input:    42,
+			expected: format_any(nil),
+			error:    errInvalid,
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection), err
+			},
+		},
+	}
+
+	run_tests_on("New", scenarios, t)
+}
+
+func Test_Filterable_UnwrapInto(t *testing.T) {
+	type user struct {
+		Name string
+	}
+
+	scenarios := []testScenario{
+		{
+			name:     "when filling a typed slice",
+			input:    sliceInput,
+			expected: format_any([]int{2, 4, 6}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				var evens []int
+				err := collection.Where(func(value interface{}) bool {
+					return value.(int)%2 == 0
+				}).UnwrapInto(&evens)
+				return format_any(evens), err
+			},
+		},
+		{
+			name:     "when replacing an existing slice",
+			input:    []user{{"ann"}},
+			expected: format_any([]user{{"ann"}}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				users := []user{{"old"}, {"older"}}
+				err := collection.UnwrapInto(&users)
+				return format_any(users), err
+			},
+		},
+		{
+			name:     "when filling a slice of interfaces",
+			input:    []interface{}{1, nil, "x"},
+			expected: format_any([]fmt.Stringer{nil}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				var stringers []fmt.Stringer
+				err := collection.Skip(1).Take(1).UnwrapInto(&stringers)
+				return format_any(stringers), err
+			},
+		},
+		{
+			name:     "when an element has the wrong type",
+			input:    []interface{}{1, 2, "three"},
+			expected: format_any("element 2 is string, not int"),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				var numbers []int
+				return format_any(collection.UnwrapInto(&numbers)), nil
+			},
+		},
+		{
+			name:     "when the target is not a slice pointer",
+			input:    sliceInput,
+			expected: format_any("argument not a valid slice pointer"),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				var numbers []int
+				return format_any(collection.UnwrapInto(numbers)), nil
+			},
+		},
+		{
+			name:     "when converting to a slice of a given type",
+			input:    []string{"a", "b"},
+			expected: format_any([]string{"a", "b"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				slice, err := collection.ToSliceOf(reflect.TypeOf(""))
+				return format_any(slice.([]string)), err
+			},
+		},
+		{
+			name:     "when no element type is given",
+			input:    []string{"a", "b"},
+			expected: format_any([]interface{}{nil, "argument not a valid element type"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				slice, err := collection.ToSliceOf(nil)
+				return format_any([]interface{}{slice, err}), nil
+			},
+		},
+	}
+
+	run_tests_on("UnwrapInto", scenarios, t)
+}
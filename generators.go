@@ -0,0 +1,155 @@
+package filterable
+
+import (
+	"context"
+	"time"
+)
+
+// RangeStep yields start, start+step, ... up to but excluding stop. A negative
+// step counts down, and a zero step, or one pointing away from stop, yields
+// nothing.
+func RangeStep(start int, stop int, step int) *Lazy {
+	return &Lazy{iterate: func(context.Context) iterator {
+		value, done := start, step == 0 || step > 0 && start >= stop || step < 0 && start <= stop
+
+		return func() (interface{}, bool) {
+			if done {
+				return nil, false
+			}
+
+			current := value
+
+			// the distance to stop always fits a uint, so stepping is only
+			// done when it stays short of stop and therefore cannot overflow
+			if step > 0 {
+				done = uint(stop-value) <= uint(step)
+			} else {
+				done = uint(value-stop) <= uint(-step)
+			}
+
+			if !done {
+				value += step
+			}
+
+			return current, true
+		}
+	}}
+}
+
+// RangeFloat is RangeStep for floats. Each value is computed as start+i*step,
+// so rounding errors do not accumulate.
+func RangeFloat(start float64, stop float64, step float64) *Lazy {
+	return &Lazy{iterate: func(context.Context) iterator {
+		index := 0
+
+		return func() (interface{}, bool) {
+			value := start + float64(index)*step
+
+			if step == 0 || step > 0 && value >= stop || step < 0 && value <= stop {
+				return nil, false
+			}
+
+			index++
+			return value, true
+		}
+	}}
+}
+
+// RangeTime is RangeStep for times, e.g. the days of a calendar month.
+func RangeTime(start time.Time, stop time.Time, step time.Duration) *Lazy {
+	return &Lazy{iterate: func(context.Context) iterator {
+		index := 0
+
+		return func() (interface{}, bool) {
+			value := start.Add(time.Duration(index) * step)
+
+			if step == 0 || step > 0 && !value.Before(stop) || step < 0 && !value.After(stop) {
+				return nil, false
+			}
+
+			index++
+			return value, true
+		}
+	}}
+}
+
+func Repeat(value interface{}, count int) *Lazy {
+	return &Lazy{iterate: func(context.Context) iterator {
+		repeated := 0
+
+		return func() (interface{}, bool) {
+			if repeated >= count {
+				return nil, false
+			}
+
+			repeated++
+			return value, true
+		}
+	}}
+}
+
+// Generate yields seed, next(seed), next(next(seed)), ... for as long as
+// while holds. A nil while never stops, so bound the query with Take or
+// TakeWhile.
+func Generate(seed interface{}, next func(interface{}) interface{}, while func(interface{}) bool) *Lazy {
+	return &Lazy{iterate: func(context.Context) iterator {
+		value, started := seed, false
+
+		return func() (interface{}, bool) {
+			if started {
+				value = next(value)
+			}
+
+			started = true
+
+			if while != nil && !while(value) {
+				return nil, false
+			}
+
+			return value, true
+		}
+	}}
+}
+
+// Unfold builds a sequence from a state: step returns the next value, the
+// following state, and false once the sequence is over.
+func Unfold(seed interface{}, step func(interface{}) (interface{}, interface{}, bool)) *Lazy {
+	return &Lazy{iterate: func(context.Context) iterator {
+		state, done := seed, false
+
+		return func() (interface{}, bool) {
+			if done {
+				return nil, false
+			}
+
+			value, next, ok := step(state)
+
+			if !ok {
+				done = true
+				return nil, false
+			}
+
+			state = next
+			return value, true
+		}
+	}}
+}
+
+// Cycle repeats items forever, so bound the query with Take or TakeWhile. An
+// empty Filterable yields nothing.
+func Cycle(items *Filterable) *Lazy {
+	source := *items
+
+	return &Lazy{iterate: func(context.Context) iterator {
+		index := 0
+
+		return func() (interface{}, bool) {
+			if len(source) == 0 {
+				return nil, false
+			}
+
+			index++
+			return source[(index-1)%len(source)], true
+		}
+	}}
+}
@@ -0,0 +1,189 @@
+package filterable
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func Test_Lazy_RangeStep(t *testing.T) {
+	type stepRange struct {
+		start, stop, step int
+	}
+
+	scenarios := []testScenario{
+		{
+			name:     "when stepping up",
+			input:    stepRange{0, 10, 3},
+			expected: format_any([]int{0, 3, 6, 9}),
+			action: func(input interface{}) (string, error) {
+				r := input.(stepRange)
+				return format_any(RangeStep(r.start, r.stop, r.step).Unwrap()), nil
+			},
+		},
+		{
+			name:     "when stepping down",
+			input:    stepRange{5, 0, -2},
+			expected: format_any([]int{5, 3, 1}),
+			action: func(input interface{}) (string, error) {
+				r := input.(stepRange)
+				return format_any(RangeStep(r.start, r.stop, r.step).Unwrap()), nil
+			},
+		},
+		{
+			name:     "when the step points away from stop",
+			input:    stepRange{0, 10, -1},
+			expected: format_any([]int{}),
+			action: func(input interface{}) (string, error) {
+				r := input.(stepRange)
+				return format_any(RangeStep(r.start, r.stop, r.step).Unwrap()), nil
+			},
+		},
+		{
+			name:     "when stepping past the largest int",
+			input:    stepRange{math.MaxInt - 1, math.MaxInt, 5},
+			expected: format_any([]int{math.MaxInt - 1}),
+			action: func(input interface{}) (string, error) {
+				r := input.(stepRange)
+				return format_any(RangeStep(r.start, r.stop, r.step).Unwrap()), nil
+			},
+		},
+		{
+			name:     "when stepping down past the smallest int",
+			input:    stepRange{math.MinInt + 3, math.MinInt, -2},
+			expected: format_any([]int{math.MinInt + 3, math.MinInt + 1}),
+			action: func(input interface{}) (string, error) {
+				r := input.(stepRange)
+				return format_any(RangeStep(r.start, r.stop, r.step).Unwrap()), nil
+			},
+		},
+		{
+			name:     "when the step is zero",
+			input:    stepRange{0, 10, 0},
+			expected: format_any([]int{}),
+			action: func(input interface{}) (string, error) {
+				r := input.(stepRange)
+				return format_any(RangeStep(r.start, r.stop, r.step).Unwrap()), nil
+			},
+		},
+		{
+			name:     "when stepping through floats",
+			input:    0.1,
+			expected: format_any([]float64{0, 0.1, 0.2, 0.30000000000000004, 0.4}),
+			action: func(input interface{}) (string, error) {
+				return format_any(RangeFloat(0, 0.5, input.(float64)).Unwrap()), nil
+			},
+		},
+		{
+			name:     "when stepping down through floats",
+			input:    -0.5,
+			expected: format_any([]float64{1, 0.5, 0}),
+			action: func(input interface{}) (string, error) {
+				return format_any(RangeFloat(1, -0.5, input.(float64)).Unwrap()), nil
+			},
+		},
+		{
+			name:     "when stepping through days",
+			input:    time.Date(2021, 2, 27, 0, 0, 0, 0, time.UTC),
+			expected: format_any([]string{"Feb 27", "Feb 28", "Mar  1"}),
+			action: func(input interface{}) (string, error) {
+				start := input.(time.Time)
+				days := RangeTime(start, start.AddDate(0, 0, 3), 24*time.Hour).Select(func(value interface{}) interface{} {
+					return value.(time.Time).Format(time.Stamp)[:6]
+				})
+				return format_any(days.Unwrap()), nil
+			},
+		},
+		{
+			name:     "when stepping back through hours",
+			input:    time.Date(2021, 1, 1, 3, 0, 0, 0, time.UTC),
+			expected: format_any([]int{3, 2}),
+			action: func(input interface{}) (string, error) {
+				start := input.(time.Time)
+				hours := RangeTime(start, start.Add(-2*time.Hour), -time.Hour).Select(func(value interface{}) interface{} {
+					return value.(time.Time).Hour()
+				})
+				return format_any(hours.Unwrap()), nil
+			},
+		},
+	}
+
+	run_tests_on("RangeStep", scenarios, t)
+}
+
+func Test_Lazy_Generators(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when repeating a value",
+			input:    "x",
+			expected: format_any([]string{"x", "x", "x"}),
+			action: func(input interface{}) (string, error) {
+				return format_any(Repeat(input, 3).Unwrap()), nil
+			},
+		},
+		{
+			name:     "when repeating a value a negative number of times",
+			input:    "x",
+			expected: format_any([]string{}),
+			action: func(input interface{}) (string, error) {
+				return format_any(Repeat(input, -1).Unwrap()), nil
+			},
+		},
+		{
+			name:     "when generating while a condition holds",
+			input:    1,
+			expected: format_any([]int{1, 2, 4, 8, 16}),
+			action: func(input interface{}) (string, error) {
+				powers := Generate(input, func(value interface{}) interface{} {
+					return value.(int) * 2
+				}, func(value interface{}) bool {
+					return value.(int) < 20
+				})
+				return format_any(powers.Unwrap()), nil
+			},
+		},
+		{
+			name:     "when generating an unbounded sequence",
+			input:    1,
+			expected: format_any([]int{1, 2, 4}),
+			action: func(input interface{}) (string, error) {
+				powers := Generate(input, func(value interface{}) interface{} {
+					return value.(int) * 2
+				}, nil)
+				return format_any(powers.Take(3).Unwrap()), nil
+			},
+		},
+		{
+			name:     "when unfolding a state",
+			input:    [2]int{0, 1},
+			expected: format_any([]int{0, 1, 1, 2, 3, 5, 8}),
+			action: func(input interface{}) (string, error) {
+				fibonacci := Unfold(input, func(state interface{}) (interface{}, interface{}, bool) {
+					pair := state.([2]int)
+					return pair[0], [2]int{pair[1], pair[0] + pair[1]}, pair[0] < 10
+				})
+				return format_any(fibonacci.Unwrap()), nil
+			},
+		},
+		{
+			name:     "when cycling through values",
+			input:    []string{"mon", "tue"},
+			expected: format_any([]string{"mon", "tue", "mon", "tue", "mon"}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(Cycle(collection).Take(5).Unwrap()), err
+			},
+		},
+		{
+			name:     "when cycling through an empty slice",
+			input:    emptyInput,
+			expected: format_any([]int{}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(Cycle(collection).Take(5).Unwrap()), err
+			},
+		},
+	}
+
+	run_tests_on("Generators", scenarios, t)
+}
@@ -0,0 +1,52 @@
+package filterable
+
+import (
+	"fmt"
+)
+
+// Grouping holds the elements that share a key. It embeds *Filterable, so
+// Count, Where, OrderBy and the rest can be called on a group directly.
+type Grouping struct {
+	Key interface{}
+	*Filterable
+}
+
+func (group Grouping) String() string {
+	return fmt.Sprintf("%v: %v", group.Key, *group.Filterable)
+}
+
+// GroupBy returns a Filterable of Grouping values in the order their keys
+// were first seen.
+func (items *Filterable) GroupBy(keySelector func(interface{}) interface{}) *Filterable {
+	return items.GroupByWithElement(keySelector, identity)
+}
+
+func (items *Filterable) GroupByWithElement(keySelector func(interface{}) interface{}, elementSelector func(interface{}) interface{}) *Filterable {
+	groups := []*Filterable{}
+	keys := Filterable{}
+	positions := map[interface{}]int{}
+
+	for _, item := range *items {
+		key := keySelector(item)
+
+		position, seen := positions[key]
+
+		if !seen {
+			position = len(groups)
+			positions[key] = position
+
+			groups = append(groups, &Filterable{})
+			keys = append(keys, key)
+		}
+
+		*groups[position] = append(*groups[position], elementSelector(item))
+	}
+
+	projection := make(Filterable, len(groups))
+
+	for index, group := range groups {
+		projection[index] = Grouping{Key: keys[index], Filterable: group}
+	}
+
+	return &projection
+}
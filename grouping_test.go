@@ -0,0 +1,72 @@
+package filterable
+
+import (
+	"testing"
+)
+
+func Test_Filterable_GroupBy(t *testing.T) {
+	department := func(value interface{}) interface{} {
+		return value.(employee).Department
+	}
+
+	scenarios := []testScenario{
+		{
+			name:     "when an empty slice is given",
+			input:    emptyInput,
+			expected: format_any([]int{}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.GroupBy(identity).Unwrap()), err
+			},
+		},
+		{
+			name:     "when grouping numbers by parity",
+			input:    []int{2, 1, 4, 3, 6},
+			expected: format_any([]string{"0: [2 4 6]", "1: [1 3]"}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				groups := collection.GroupBy(func(value interface{}) interface{} {
+					return value.(int) % 2
+				})
+				return format_any(groups.Unwrap()), err
+			},
+		},
+		{
+			name:     "when selecting the elements of each group",
+			input:    employees,
+			expected: format_any([]string{"Sales: [Smith Adams]", "IT: [Jones Jones Brown]"}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				groups := collection.GroupByWithElement(department, func(value interface{}) interface{} {
+					return value.(employee).LastName
+				})
+				return format_any(groups.Unwrap()), err
+			},
+		},
+		{
+			name:     "when chaining operators on each group",
+			input:    employees,
+			expected: format_any([]string{"IT: 3 [Brown Jones Jones]", "Sales: 2 [Adams Smith]"}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				summary := collection.GroupBy(department).
+					OrderBy(func(value interface{}) interface{} {
+						return value.(Grouping).Key
+					}).
+					AsFilterable().
+					Select(func(value interface{}) interface{} {
+						group := value.(Grouping)
+						names := group.OrderBy(func(value interface{}) interface{} {
+							return value.(employee).LastName
+						}).AsFilterable().Select(func(value interface{}) interface{} {
+							return value.(employee).LastName
+						})
+						return format_any(group.Key) + ": " + format_any(group.Count()) + " " + format_any(names.Unwrap())
+					})
+				return format_any(summary.Unwrap()), err
+			},
+		},
+	}
+
+	run_tests_on("GroupBy", scenarios, t)
+}
@@ -0,0 +1,116 @@
+package filterable
+
+// Joins hash the inner sequence by key and walk the outer sequence, so results
+// follow the outer order and, within one outer element, the inner order.
+// As in LINQ, nil keys never match anything.
+
+func (items *Filterable) Join(inner *Filterable, outerKey func(interface{}) interface{}, innerKey func(interface{}) interface{}, resultSelector func(interface{}, interface{}) interface{}) *Filterable {
+	lookup := inner.ToLookup(innerKey)
+
+	projection := Filterable{}
+
+	for _, outer := range *items {
+		for _, match := range *joinMatches(lookup, outerKey(outer)) {
+			projection = append(projection, resultSelector(outer, match))
+		}
+	}
+
+	return &projection
+}
+
+func (items *Filterable) GroupJoin(inner *Filterable, outerKey func(interface{}) interface{}, innerKey func(interface{}) interface{}, resultSelector func(interface{}, *Filterable) interface{}) *Filterable {
+	lookup := inner.ToLookup(innerKey)
+
+	projection := make(Filterable, len(*items))
+
+	for index, outer := range *items {
+		projection[index] = resultSelector(outer, joinMatches(lookup, outerKey(outer)))
+	}
+
+	return &projection
+}
+
+// LeftJoin is Join that also keeps outer elements without a match, pairing
+// them with a nil inner element.
+func (items *Filterable) LeftJoin(inner *Filterable, outerKey func(interface{}) interface{}, innerKey func(interface{}) interface{}, resultSelector func(interface{}, interface{}) interface{}) *Filterable {
+	lookup := inner.ToLookup(innerKey)
+
+	projection := Filterable{}
+
+	for _, outer := range *items {
+		matches := joinMatches(lookup, outerKey(outer))
+
+		if len(*matches) == 0 {
+			projection = append(projection, resultSelector(outer, nil))
+		}
+
+		for _, match := range *matches {
+			projection = append(projection, resultSelector(outer, match))
+		}
+	}
+
+	return &projection
+}
+
+// FullOuterJoin is LeftJoin followed by the unmatched inner elements, in inner
+// order and paired with a nil outer element.
+func (items *Filterable) FullOuterJoin(inner *Filterable, outerKey func(interface{}) interface{}, innerKey func(interface{}) interface{}, resultSelector func(interface{}, interface{}) interface{}) *Filterable {
+	// each inner key is selected once and the lookup holds inner positions, so
+	// the unmatched ones can be found without selecting the keys again
+	keys := make([]interface{}, len(*inner))
+
+	for position, item := range *inner {
+		keys[position] = innerKey(item)
+	}
+
+	lookup := Range(0, len(*inner)).ToLookup(func(position interface{}) interface{} {
+		return keys[position.(int)]
+	})
+
+	matched := make([]bool, len(*inner))
+
+	projection := Filterable{}
+
+	for _, outer := range *items {
+		positions := joinMatches(lookup, outerKey(outer))
+
+		if len(*positions) == 0 {
+			projection = append(projection, resultSelector(outer, nil))
+			continue
+		}
+
+		for _, position := range *positions {
+			matched[position.(int)] = true
+			projection = append(projection, resultSelector(outer, (*inner)[position.(int)]))
+		}
+	}
+
+	for position, item := range *inner {
+		if !matched[position] {
+			projection = append(projection, resultSelector(nil, item))
+		}
+	}
+
+	return &projection
+}
+
+func joinMatches(lookup *Lookup, key interface{}) *Filterable {
+	if key == nil {
+		return &Filterable{}
+	}
+
+	return lookup.Get(key)
+}
+
+// CrossJoin pairs every element with every element of collection.
+func (items *Filterable) CrossJoin(collection *Filterable, resultSelector func(interface{}, interface{}) interface{}) *Filterable {
+	projection := make(Filterable, 0, len(*items)*len(*collection))
+
+	for _, outer := range *items {
+		for _, inner := range *collection {
+			projection = append(projection, resultSelector(outer, inner))
+		}
+	}
+
+	return &projection
+}
@@ -0,0 +1,142 @@
+package filterable
+
+import (
+	"testing"
+)
+
+type customer struct {
+	ID   interface{}
+	Name string
+}
+
+type order struct {
+	CustomerID interface{}
+	Item       string
+}
+
+var (
+	customers = []customer{{1, "Ada"}, {2, "Bo"}, {3, "Cy"}, {nil, "Anon"}}
+	orders    = []order{{2, "pen"}, {1, "ink"}, {2, "pad"}, {4, "cup"}, {nil, "box"}}
+
+	customerID = func(value interface{}) interface{} { return value.(customer).ID }
+	orderOwner = func(value interface{}) interface{} { return value.(order).CustomerID }
+	pairNames  = func(outer interface{}, inner interface{}) interface{} {
+		name, item := "-", "-"
+		if outer != nil {
+			name = outer.(customer).Name
+		}
+		if inner != nil {
+			item = inner.(order).Item
+		}
+		return name + ":" + item
+	}
+)
+
+func Test_Filterable_Join(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when joining with an empty collection",
+			input:    customers,
+			expected: format_any([]string{}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				result := collection.Join(&Filterable{}, customerID, orderOwner, pairNames)
+				return format_any(result.Unwrap()), err
+			},
+		},
+		{
+			name:     "when joining on matching keys",
+			input:    customers,
+			expected: format_any([]string{"Ada:ink", "Bo:pen", "Bo:pad"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				inner, err := New(orders)
+				result := collection.Join(inner, customerID, orderOwner, pairNames)
+				return format_any(result.Unwrap()), err
+			},
+		},
+		{
+			name:     "when group joining",
+			input:    customers,
+			expected: format_any([]string{"Ada:1", "Bo:2", "Cy:0", "Anon:0"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				inner, err := New(orders)
+				result := collection.GroupJoin(inner, customerID, orderOwner, func(outer interface{}, matches *Filterable) interface{} {
+					return outer.(customer).Name + ":" + format_any(matches.Count())
+				})
+				return format_any(result.Unwrap()), err
+			},
+		},
+		{
+			name:     "when left joining",
+			input:    customers,
+			expected: format_any([]string{"Ada:ink", "Bo:pen", "Bo:pad", "Cy:-", "Anon:-"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				inner, err := New(orders)
+				result := collection.LeftJoin(inner, customerID, orderOwner, pairNames)
+				return format_any(result.Unwrap()), err
+			},
+		},
+		{
+			name:     "when full outer joining",
+			input:    customers,
+			expected: format_any([]string{"Ada:ink", "Bo:pen", "Bo:pad", "Cy:-", "Anon:-", "-:cup", "-:box"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				inner, err := New(orders)
+				result := collection.FullOuterJoin(inner, customerID, orderOwner, pairNames)
+				return format_any(result.Unwrap()), err
+			},
+		},
+		{
+			name:     "when full outer joining selects each inner key once",
+			input:    customers,
+			expected: format_any(len(orders)),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				inner, err := New(orders)
+				calls := 0
+				collection.FullOuterJoin(inner, customerID, func(value interface{}) interface{} {
+					calls++
+					return orderOwner(value)
+				}, pairNames)
+				return format_any(calls), err
+			},
+		},
+	}
+
+	run_tests_on("Join", scenarios, t)
+}
+
+func Test_Filterable_CrossJoin(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when one collection is empty",
+			input:    emptyInput,
+			expected: format_any([]string{}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				result := collection.CrossJoin(Range(1, 3), func(first interface{}, second interface{}) interface{} {
+					return format_any(first) + format_any(second)
+				})
+				return format_any(result.Unwrap()), err
+			},
+		},
+		{
+			name:     "when pairing every element",
+			input:    []string{"a", "b"},
+			expected: format_any([]string{"a1", "a2", "b1", "b2"}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				result := collection.CrossJoin(Range(1, 2), func(first interface{}, second interface{}) interface{} {
+					return format_any(first) + format_any(second)
+				})
+				return format_any(result.Unwrap()), err
+			},
+		},
+	}
+
+	run_tests_on("CrossJoin", scenarios, t)
+}
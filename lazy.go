@@ -0,0 +1,350 @@
+package filterable
+
+import (
+	"context"
+)
+
+// iterator yields the next element of a sequence and reports whether one was produced.
+type iterator func() (interface{}, bool)
+
+// Lazy is a deferred query. Operators compose iterator stages and nothing is
+// evaluated until a terminal operation (Unwrap, First, Count, Any, ...) runs,
+// so Take, First and Any stop pulling from the source as soon as they can.
+// Each terminal operation re-runs the pipeline from its source.
+//
+// The pipeline is built for a context, and every stage stops pulling from its
+// source once that context is done (see WithContext).
+type Lazy struct {
+	iterate func(ctx context.Context) iterator
+}
+
+func (items *Filterable) AsLazy() *Lazy {
+	source := *items
+
+	return &Lazy{iterate: func(context.Context) iterator {
+		index := 0
+
+		return func() (interface{}, bool) {
+			if index >= len(source) {
+				return nil, false
+			}
+
+			index++
+			return source[index-1], true
+		}
+	}}
+}
+
+// FromIterator returns a lazy query over source. Like a channel, an iterator
+// is consumed as the query pulls from it.
+func FromIterator(source Iterator) *Lazy {
+	return &Lazy{iterate: func(context.Context) iterator {
+		return source.Next
+	}}
+}
+
+func LazyRange(start int, count int) *Lazy {
+	return &Lazy{iterate: func(context.Context) iterator {
+		value, stop := start, start+count
+
+		return func() (interface{}, bool) {
+			if value >= stop {
+				return nil, false
+			}
+
+			value++
+			return value - 1, true
+		}
+	}}
+}
+
+func (query *Lazy) stage(compose func(source iterator) iterator) *Lazy {
+	iterate := query.iterate
+
+	return &Lazy{iterate: func(ctx context.Context) iterator {
+		return compose(stopOnDone(ctx, iterate(ctx)))
+	}}
+}
+
+// start builds the pipeline for terminals that take no context.
+func (query *Lazy) start() iterator {
+	return query.iterate(context.Background())
+}
+
+// stopOnDone ends source once ctx is done, so stages that pull several
+// elements per call, such as Where, notice cancellation between them.
+// Contexts that can never be cancelled are not checked at all.
+func stopOnDone(ctx context.Context, source iterator) iterator {
+	if ctx.Done() == nil {
+		return source
+	}
+
+	return func() (interface{}, bool) {
+		if ctx.Err() != nil {
+			return nil, false
+		}
+
+		return source()
+	}
+}
+
+func (query *Lazy) Where(predicate func(interface{}) bool) *Lazy {
+	return query.WhereIndexed(func(_ int, value interface{}) bool {
+		return predicate(value)
+	})
+}
+
+func (query *Lazy) WhereIndexed(predicate func(int, interface{}) bool) *Lazy {
+	return query.stage(func(source iterator) iterator {
+		index := 0
+
+		return func() (interface{}, bool) {
+			for item, ok := source(); ok; item, ok = source() {
+				index++
+
+				if predicate(index-1, item) {
+					return item, true
+				}
+			}
+
+			return nil, false
+		}
+	})
+}
+
+func (query *Lazy) Select(keySelector func(interface{}) interface{}) *Lazy {
+	return query.SelectIndexed(func(_ int, value interface{}) interface{} {
+		return keySelector(value)
+	})
+}
+
+func (query *Lazy) SelectIndexed(keySelector func(int, interface{}) interface{}) *Lazy {
+	return query.stage(func(source iterator) iterator {
+		index := 0
+
+		return func() (interface{}, bool) {
+			for item, ok := source(); ok; item, ok = source() {
+				index++
+
+				if key := keySelector(index-1, item); key != empty {
+					return key, true
+				}
+			}
+
+			return nil, false
+		}
+	})
+}
+
+func (query *Lazy) SelectMany(selector func(interface{}) *Filterable) *Lazy {
+	return query.stage(func(source iterator) iterator {
+		children := Filterable{}
+
+		return func() (interface{}, bool) {
+			for len(children) == 0 {
+				item, ok := source()
+
+				if !ok {
+					return nil, false
+				}
+
+				if selected := selector(item); selected != nil {
+					children = *selected
+				}
+			}
+
+			child := children[0]
+			children = children[1:]
+
+			return child, true
+		}
+	})
+}
+
+func (query *Lazy) Distinct() *Lazy {
+	return query.DistinctBy(func(value interface{}) interface{} {
+		return value
+	})
+}
+
+func (query *Lazy) DistinctBy(keySelector func(interface{}) interface{}) *Lazy {
+	return query.stage(func(source iterator) iterator {
+		set := map[interface{}]bool{}
+
+		return func() (interface{}, bool) {
+			for item, ok := source(); ok; item, ok = source() {
+				key := keySelector(item)
+
+				if _, seen := set[key]; !seen {
+					set[key] = true
+					return item, true
+				}
+			}
+
+			return nil, false
+		}
+	})
+}
+
+func (query *Lazy) Skip(count int) *Lazy {
+	return query.SkipWhileIndexed(func(index int, _ interface{}) bool {
+		return index < count
+	})
+}
+
+func (query *Lazy) SkipWhile(predicate func(interface{}) bool) *Lazy {
+	return query.SkipWhileIndexed(func(_ int, value interface{}) bool {
+		return predicate(value)
+	})
+}
+
+func (query *Lazy) SkipWhileIndexed(predicate func(int, interface{}) bool) *Lazy {
+	return query.stage(func(source iterator) iterator {
+		index, skipping := 0, true
+
+		return func() (interface{}, bool) {
+			for item, ok := source(); ok; item, ok = source() {
+				if skipping && predicate(index, item) {
+					index++
+					continue
+				}
+
+				skipping = false
+				return item, true
+			}
+
+			return nil, false
+		}
+	})
+}
+
+func (query *Lazy) Take(count int) *Lazy {
+	return query.stage(func(source iterator) iterator {
+		taken := 0
+
+		return func() (interface{}, bool) {
+			// never pull past count, so an infinite or blocking source is left alone
+			if taken >= count {
+				return nil, false
+			}
+
+			taken++
+			return source()
+		}
+	})
+}
+
+func (query *Lazy) TakeWhile(predicate func(interface{}) bool) *Lazy {
+	return query.TakeWhileIndexed(func(_ int, value interface{}) bool {
+		return predicate(value)
+	})
+}
+
+func (query *Lazy) TakeWhileIndexed(predicate func(int, interface{}) bool) *Lazy {
+	return query.stage(func(source iterator) iterator {
+		index, done := 0, false
+
+		return func() (interface{}, bool) {
+			if done {
+				return nil, false
+			}
+
+			item, ok := source()
+
+			if !ok || !predicate(index, item) {
+				done = true
+				return nil, false
+			}
+
+			index++
+			return item, true
+		}
+	})
+}
+
+func (query *Lazy) Unwrap() Filterable {
+	projection := Filterable{}
+
+	next := query.start()
+
+	for item, ok := next(); ok; item, ok = next() {
+		projection = append(projection, item)
+	}
+
+	return projection
+}
+
+func (query *Lazy) AsFilterable() *Filterable {
+	projection := query.Unwrap()
+	return &projection
+}
+
+func (query *Lazy) Any(predicate func(interface{}) bool) bool {
+	next := query.start()
+
+	for item, ok := next(); ok; item, ok = next() {
+		if predicate(item) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (query *Lazy) All(predicate func(interface{}) bool) bool {
+	return !query.Any(func(value interface{}) bool {
+		return !predicate(value)
+	})
+}
+
+func (query *Lazy) First() interface{} {
+	if item, ok := query.start()(); ok {
+		return item
+	}
+
+	return nil
+}
+
+func (query *Lazy) FirstWhere(predicate func(interface{}) bool) interface{} {
+	return query.Where(predicate).First()
+}
+
+func (query *Lazy) Last() interface{} {
+	return query.LastWhere(func(interface{}) bool {
+		return true
+	})
+}
+
+func (query *Lazy) LastWhere(predicate func(interface{}) bool) interface{} {
+	var last interface{}
+
+	next := query.start()
+
+	for item, ok := next(); ok; item, ok = next() {
+		if predicate(item) {
+			last = item
+		}
+	}
+
+	return last
+}
+
+func (query *Lazy) Count() int {
+	return query.CountWhere(func(interface{}) bool {
+		return true
+	})
+}
+
+func (query *Lazy) CountWhere(predicate func(interface{}) bool) int {
+	count := 0
+
+	next := query.start()
+
+	for item, ok := next(); ok; item, ok = next() {
+		if predicate(item) {
+			count++
+		}
+	}
+
+	return count
+}
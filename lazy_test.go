@@ -0,0 +1,278 @@
+package filterable
+
+import (
+	"testing"
+)
+
+func Test_Lazy_AsLazy(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when an empty slice is given",
+			input:    emptyInput,
+			expected: format_any([]int{}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.AsLazy().Unwrap()), err
+			},
+		},
+		{
+			name:     "when a valid slice is given",
+			input:    sliceInput,
+			expected: format_any(sliceInput),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.AsLazy().Unwrap()), err
+			},
+		},
+		{
+			name:     "when the query is evaluated more than once",
+			input:    sliceInput,
+			expected: format_any([]int{7, 7}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				query := collection.AsLazy()
+				return format_any([]int{query.Count(), query.Count()}), err
+			},
+		},
+	}
+
+	run_tests_on("AsLazy", scenarios, t)
+}
+
+func Test_Lazy_LazyRange(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when a negative count is given",
+			input:    -1,
+			expected: format_any([]int{}),
+			action: func(input interface{}) (string, error) {
+				return format_any(LazyRange(0, input.(int)).Unwrap()), nil
+			},
+		},
+		{
+			name:     "when a positive count is given",
+			input:    7,
+			expected: format_any(sliceInput),
+			action: func(input interface{}) (string, error) {
+				return format_any(LazyRange(1, input.(int)).Unwrap()), nil
+			},
+		},
+	}
+
+	run_tests_on("LazyRange", scenarios, t)
+}
+
+func Test_Lazy_Where(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when filtering odd numbers",
+			input:    sliceInput,
+			expected: format_any([]int{1, 3, 5, 7}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				query := collection.AsLazy().Where(func(value interface{}) bool {
+					return value.(int)%2 == 1
+				})
+				return format_any(query.Unwrap()), err
+			},
+		},
+		{
+			name:     "when followed by Take on a large range",
+			input:    10_000_000,
+			expected: format_any([]int{2, 4, 6, 8, 10, 10}),
+			action: func(input interface{}) (string, error) {
+				calls := 0
+				values := LazyRange(1, input.(int)).Where(func(value interface{}) bool {
+					calls++
+					return value.(int)%2 == 0
+				}).Take(5).Unwrap()
+				return format_any(append(values, calls)), nil
+			},
+		},
+	}
+
+	run_tests_on("Where", scenarios, t)
+}
+
+func Test_Lazy_Select(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when doubling values",
+			input:    sliceInput,
+			expected: format_any([]int{2, 4, 6, 8, 10, 12, 14}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				query := collection.AsLazy().Select(func(value interface{}) interface{} {
+					return value.(int) * 2
+				})
+				return format_any(query.Unwrap()), err
+			},
+		},
+		{
+			name:     "when selecting Empty() for some values",
+			input:    sliceInput,
+			expected: format_any([]int{0, 2, 4, 6}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				query := collection.AsLazy().SelectIndexed(func(index int, value interface{}) interface{} {
+					if value.(int)%2 == 0 {
+						return Empty()
+					}
+					return index
+				})
+				return format_any(query.Unwrap()), err
+			},
+		},
+	}
+
+	run_tests_on("Select", scenarios, t)
+}
+
+func Test_Lazy_Distinct(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when slice contains duplicates",
+			input:    append(sliceInput, sliceInput...),
+			expected: format_any(sliceInput),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.AsLazy().Distinct().Unwrap()), err
+			},
+		},
+		{
+			name:     "when deduping by key",
+			input:    sliceInput,
+			expected: format_any([]int{1, 2}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				query := collection.AsLazy().DistinctBy(func(value interface{}) interface{} {
+					return value.(int) % 2
+				})
+				return format_any(query.Unwrap()), err
+			},
+		},
+	}
+
+	run_tests_on("Distinct", scenarios, t)
+}
+
+func Test_Lazy_Skip(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when skipping a few values",
+			input:    sliceInput,
+			expected: format_any([]int{3, 4, 5, 6, 7}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.AsLazy().Skip(2).Unwrap()), err
+			},
+		},
+		{
+			name:     "when skipping more values than available",
+			input:    sliceInput,
+			expected: format_any([]int{}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.AsLazy().Skip(10).Unwrap()), err
+			},
+		},
+		{
+			name:     "when skipping while values are small",
+			input:    []int{1, 2, 5, 1, 2},
+			expected: format_any([]int{5, 1, 2}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				query := collection.AsLazy().SkipWhile(func(value interface{}) bool {
+					return value.(int) < 3
+				})
+				return format_any(query.Unwrap()), err
+			},
+		},
+	}
+
+	run_tests_on("Skip", scenarios, t)
+}
+
+func Test_Lazy_Take(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when taking a few values",
+			input:    sliceInput,
+			expected: format_any([]int{1, 2}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.AsLazy().Take(2).Unwrap()), err
+			},
+		},
+		{
+			name:     "when taking a negative count",
+			input:    sliceInput,
+			expected: format_any([]int{}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.AsLazy().Take(-1).Unwrap()), err
+			},
+		},
+		{
+			name:     "when taking while values are small",
+			input:    []int{1, 2, 5, 1, 2},
+			expected: format_any([]int{1, 2}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				query := collection.AsLazy().TakeWhile(func(value interface{}) bool {
+					return value.(int) < 3
+				})
+				return format_any(query.Unwrap()), err
+			},
+		},
+	}
+
+	run_tests_on("Take", scenarios, t)
+}
+
+func Test_Lazy_Terminals(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when querying an empty sequence",
+			input:    emptyInput,
+			expected: format_any([]interface{}{nil, nil, 0, false, true}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				query := collection.AsLazy()
+				isOdd := func(value interface{}) bool { return value.(int)%2 == 1 }
+				return format_any([]interface{}{
+					query.First(), query.Last(), query.Count(), query.Any(isOdd), query.All(isOdd),
+				}), err
+			},
+		},
+		{
+			name:     "when querying a non-empty sequence",
+			input:    sliceInput,
+			expected: format_any([]interface{}{2, 6, 4, true, false}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				query := collection.AsLazy()
+				isOdd := func(value interface{}) bool { return value.(int)%2 == 1 }
+				isEven := func(value interface{}) bool { return !isOdd(value) }
+				return format_any([]interface{}{
+					query.FirstWhere(isEven), query.LastWhere(isEven), query.CountWhere(isOdd), query.Any(isOdd), query.All(isOdd),
+				}), err
+			},
+		},
+		{
+			name:     "when Any is satisfied early",
+			input:    10_000_000,
+			expected: format_any([]interface{}{true, 3}),
+			action: func(input interface{}) (string, error) {
+				calls := 0
+				found := LazyRange(1, input.(int)).Any(func(value interface{}) bool {
+					calls++
+					return value.(int) == 3
+				})
+				return format_any([]interface{}{found, calls}), nil
+			},
+		},
+	}
+
+	run_tests_on("Terminals", scenarios, t)
+}
@@ -0,0 +1,143 @@
+package filterable
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Lookup is a read-only multi-valued map built by ToLookup. Keys keep the
+// order in which they were first seen.
+type Lookup struct {
+	groups *Filterable
+	index  map[interface{}]*Filterable
+}
+
+func (items *Filterable) ToLookup(keySelector func(interface{}) interface{}) *Lookup {
+	return items.ToLookupWithElement(keySelector, identity)
+}
+
+func (items *Filterable) ToLookupWithElement(keySelector func(interface{}) interface{}, elementSelector func(interface{}) interface{}) *Lookup {
+	groups := items.GroupByWithElement(keySelector, elementSelector)
+	index := make(map[interface{}]*Filterable, len(*groups))
+
+	for _, group := range *groups {
+		index[group.(Grouping).Key] = group.(Grouping).Filterable
+	}
+
+	return &Lookup{groups: groups, index: index}
+}
+
+// Get returns the elements stored under key, or an empty Filterable when the
+// key is missing.
+func (lookup *Lookup) Get(key interface{}) *Filterable {
+	if elements, exist := lookup.index[key]; exist {
+		projection := append(Filterable{}, *elements...)
+		return &projection
+	}
+
+	return &Filterable{}
+}
+
+func (lookup *Lookup) Contains(key interface{}) bool {
+	_, exist := lookup.index[key]
+	return exist
+}
+
+func (lookup *Lookup) Count() int {
+	return len(*lookup.groups)
+}
+
+func (lookup *Lookup) Keys() *Filterable {
+	return lookup.groups.Select(func(group interface{}) interface{} {
+		return group.(Grouping).Key
+	})
+}
+
+// Groupings returns the lookup's contents as Grouping values.
+func (lookup *Lookup) Groupings() *Filterable {
+	return lookup.groups.Select(func(group interface{}) interface{} {
+		return Grouping{Key: group.(Grouping).Key, Filterable: lookup.Get(group.(Grouping).Key)}
+	})
+}
+
+func (items *Filterable) ToMap(keySelector func(interface{}) interface{}, valueSelector func(interface{}) interface{}) (map[interface{}]interface{}, error) {
+	projection := make(map[interface{}]interface{}, len(*items))
+
+	for index, item := range *items {
+		key := keySelector(item)
+
+		if _, exist := projection[key]; exist {
+			return nil, fmt.Errorf("%w %v at element %d", ErrDuplicateKey, key, index)
+		}
+
+		projection[key] = valueSelector(item)
+	}
+
+	return projection, nil
+}
+
+// ToMapInto adds the selected keys and values to the map that target points
+// to, allocating it when it is nil. Keys and values must be assignable to the
+// map's key and element types. Every element is checked before the map is
+// touched, so on error it is left as it was. Keys already in the map are not
+// duplicates; their values are overwritten.
+func (items *Filterable) ToMapInto(target interface{}, keySelector func(interface{}) interface{}, valueSelector func(interface{}) interface{}) error {
+	pointer := reflect.ValueOf(target)
+
+	if pointer.Kind() != reflect.Ptr || pointer.IsNil() || pointer.Elem().Kind() != reflect.Map {
+		return fmt.Errorf("argument not a valid map pointer")
+	}
+
+	projection := pointer.Elem()
+	mapType := projection.Type()
+
+	keys, values := make([]reflect.Value, 0, len(*items)), make([]reflect.Value, 0, len(*items))
+	seen := map[interface{}]bool{}
+
+	for index, item := range *items {
+		key, value := keySelector(item), valueSelector(item)
+
+		mapKey, ok := assignable(key, mapType.Key())
+
+		// an interface key type still needs a hashable dynamic type
+		if !ok || !mapKey.Type().Comparable() {
+			return fmt.Errorf("key of element %d is %T, not %v", index, key, mapType.Key())
+		}
+
+		mapValue, ok := assignable(value, mapType.Elem())
+
+		if !ok {
+			return fmt.Errorf("value of element %d is %T, not %v", index, value, mapType.Elem())
+		}
+
+		if seen[key] {
+			return fmt.Errorf("%w %v at element %d", ErrDuplicateKey, key, index)
+		}
+
+		seen[key] = true
+
+		keys, values = append(keys, mapKey), append(values, mapValue)
+	}
+
+	if projection.IsNil() {
+		projection.Set(reflect.MakeMapWithSize(mapType, len(keys)))
+	}
+
+	for index, key := range keys {
+		projection.SetMapIndex(key, values[index])
+	}
+
+	return nil
+}
+
+func assignable(value interface{}, target reflect.Type) (reflect.Value, bool) {
+	if value == nil {
+		return reflect.Zero(target), isNillable(target)
+	}
+
+	if converted := reflect.ValueOf(value); converted.Type().AssignableTo(target) {
+		return converted, true
+	}
+
+	return reflect.Value{}, false
+}
@@ -0,0 +1,181 @@
+package filterable
+
+import (
+	"testing"
+)
+
+func Test_Filterable_ToLookup(t *testing.T) {
+	department := func(value interface{}) interface{} {
+		return value.(employee).Department
+	}
+	lastName := func(value interface{}) interface{} {
+		return value.(employee).LastName
+	}
+
+	scenarios := []testScenario{
+		{
+			name:     "when querying existing keys",
+			input:    employees,
+			expected: format_any([]interface{}{2, []string{"Jones", "Jones", "Brown"}, []string{"Smith", "Adams"}}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				lookup := collection.ToLookupWithElement(department, lastName)
+				return format_any([]interface{}{lookup.Count(), lookup.Get("IT").Unwrap(), lookup.Get("Sales").Unwrap()}), err
+			},
+		},
+		{
+			name:     "when querying a missing key",
+			input:    employees,
+			expected: format_any([]interface{}{false, 0}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				lookup := collection.ToLookup(department)
+				return format_any([]interface{}{lookup.Contains("HR"), lookup.Get("HR").Count()}), err
+			},
+		},
+		{
+			name:     "when listing keys and groupings",
+			input:    employees,
+			expected: format_any([]interface{}{[]string{"Sales", "IT"}, []string{"Sales: [Smith Adams]", "IT: [Jones Jones Brown]"}}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				lookup := collection.ToLookupWithElement(department, lastName)
+				return format_any([]interface{}{lookup.Keys().Unwrap(), lookup.Groupings().Unwrap()}), err
+			},
+		},
+		{
+			name:     "when the result of Get is modified",
+			input:    employees,
+			expected: format_any(3),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				lookup := collection.ToLookup(department)
+				*lookup.Get("IT") = Filterable{}
+				return format_any(lookup.Get("IT").Count()), err
+			},
+		},
+	}
+
+	run_tests_on("ToLookup", scenarios, t)
+}
+
+func Test_Filterable_ToMap(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when keys are unique",
+			input:    []string{"a", "bb", "ccc"},
+			expected: format_any(map[interface{}]interface{}{"a": 1, "bb": 2, "ccc": 3}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				result, err := collection.ToMap(identity, func(value interface{}) interface{} {
+					return len(value.(string))
+				})
+				return format_any(result), err
+			},
+		},
+		{
+			name:     "when keys are duplicated",
+			input:    []string{"a", "bb", "c"},
+			expected: format_any("duplicate key 1 at element 2"),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				_, err := collection.ToMap(func(value interface{}) interface{} {
+					return len(value.(string))
+				}, identity)
+				return format_any(err), nil
+			},
+		},
+	}
+
+	run_tests_on("ToMap", scenarios, t)
+}
+
+func Test_Filterable_ToMapInto(t *testing.T) {
+	length := func(value interface{}) interface{} {
+		return len(value.(string))
+	}
+
+	scenarios := []testScenario{
+		{
+			name:     "when filling a typed map",
+			input:    []string{"a", "bb", "ccc"},
+			expected: format_any(map[string]int{"a": 1, "bb": 2, "ccc": 3}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				var result map[string]int
+				err := collection.ToMapInto(&result, identity, length)
+				return format_any(result), err
+			},
+		},
+		{
+			name:     "when a value has the wrong type",
+			input:    []string{"a", "bb"},
+			expected: format_any("value of element 0 is string, not int"),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				result := map[int]int{}
+				return format_any(collection.ToMapInto(&result, length, identity)), nil
+			},
+		},
+		{
+			name:     "when keys are duplicated",
+			input:    []string{"a", "b"},
+			expected: format_any("duplicate key 1 at element 1"),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				result := map[int]string{}
+				return format_any(collection.ToMapInto(&result, length, identity)), nil
+			},
+		},
+		{
+			name:     "when a key is unhashable",
+			input:    []string{"a"},
+			expected: format_any([]string{"key of element 0 is []uint8, not string", "key of element 0 is []uint8, not interface {}"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				toBytes := func(value interface{}) interface{} { return []byte(value.(string)) }
+				typed, untyped := map[string]int{}, map[interface{}]int{}
+				return format_any([]string{
+					collection.ToMapInto(&typed, toBytes, length).Error(),
+					collection.ToMapInto(&untyped, toBytes, length).Error(),
+				}), nil
+			},
+		},
+		{
+			name:     "when a later element fails",
+			input:    []string{"a", "b"},
+			expected: format_any([]interface{}{map[int]string(nil), map[int]string{9: "z"}}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				var unallocated map[int]string
+				existing := map[int]string{9: "z"}
+				collection.ToMapInto(&unallocated, length, identity)
+				collection.ToMapInto(&existing, length, identity)
+				return format_any([]interface{}{unallocated, existing}), nil
+			},
+		},
+		{
+			name:     "when keys are already in the map",
+			input:    []string{"a", "bb"},
+			expected: format_any(map[int]string{1: "a", 2: "bb", 3: "old"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				result := map[int]string{1: "old", 3: "old"}
+				err := collection.ToMapInto(&result, length, identity)
+				return format_any(result), err
+			},
+		},
+		{
+			name:     "when the target is not a map pointer",
+			input:    []string{"a"},
+			expected: format_any("argument not a valid map pointer"),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				result := map[string]int{}
+				return format_any(collection.ToMapInto(result, identity, length)), nil
+			},
+		},
+	}
+
+	run_tests_on("ToMapInto", scenarios, t)
+}
@@ -0,0 +1,197 @@
+package filterable
+
+import (
+	"sort"
+	"strings"
+)
+
+// Comparer orders two values, returning a negative number when a sorts
+// before b, a positive number when it sorts after and zero when they tie.
+type Comparer interface {
+	Compare(a, b interface{}) int
+}
+
+// ComparerFunc adapts an ordinary function to a Comparer.
+type ComparerFunc func(a, b interface{}) int
+
+func (compare ComparerFunc) Compare(a, b interface{}) int {
+	return compare(a, b)
+}
+
+// LessComparer adapts a less function, as used by sort.Slice, to a Comparer.
+func LessComparer(less func(a, b interface{}) bool) Comparer {
+	return ComparerFunc(func(a, b interface{}) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		default:
+			return 0
+		}
+	})
+}
+
+// nullPlacement overrides where nil keys sort, regardless of direction.
+type nullPlacement int
+
+const (
+	nullsDefault nullPlacement = iota
+	nullsFirst
+	nullsLast
+)
+
+type orderKey struct {
+	selector   func(interface{}) interface{}
+	comparer   Comparer
+	descending bool
+	nulls      nullPlacement
+}
+
+func (key orderKey) compare(a, b interface{}) int {
+	if key.comparer == nil {
+		return compareKeys(a, b)
+	}
+
+	return key.comparer.Compare(a, b)
+}
+
+func identity(value interface{}) interface{} {
+	return value
+}
+
+// Orderable is a sorted sequence that remembers its sort keys, so ThenBy and
+// ThenByDescending can add tie-breakers without losing the earlier ordering.
+//
+// Keys are compared natively: nil first, then numbers of any kind by value,
+// strings lexically, false before true, time.Time chronologically, and types
+// with a Compare(T) int or Less(T) bool method by that method. Keys of
+// unrelated types do not panic; they are grouped by type name and then
+// ordered by their "%v" form.
+type Orderable struct {
+	source Filterable
+	keys   []orderKey
+	items  Filterable
+}
+
+func (items *Filterable) AsOrderable() *Orderable {
+	return newOrderable(*items, nil)
+}
+
+func (items *Filterable) OrderBy(selector func(object interface{}) interface{}) *Orderable {
+	return newOrderable(*items, []orderKey{{selector: selector}})
+}
+
+func (items *Filterable) OrderByDescending(selector func(object interface{}) interface{}) *Orderable {
+	return newOrderable(*items, []orderKey{{selector: selector, descending: true}})
+}
+
+func (items *Filterable) OrderByFunc(less func(a, b interface{}) bool) *Orderable {
+	return items.OrderByComparer(identity, LessComparer(less))
+}
+
+func (items *Filterable) OrderByComparer(selector func(object interface{}) interface{}, comparer Comparer) *Orderable {
+	return newOrderable(*items, []orderKey{{selector: selector, comparer: comparer}})
+}
+
+func (items *Filterable) OrderByDescendingComparer(selector func(object interface{}) interface{}, comparer Comparer) *Orderable {
+	return newOrderable(*items, []orderKey{{selector: selector, comparer: comparer, descending: true}})
+}
+
+// Order sorts by selector in the direction named by sortOrder, "asc" or
+// "desc". Any other sortOrder leaves the elements in source order; use
+// OrderBySpec to have it reported as an error instead.
+func (items *Filterable) Order(sortOrder string, selector func(object interface{}) interface{}) *Orderable {
+	switch strings.ToLower(sortOrder) {
+	case "asc":
+		return items.OrderBy(selector)
+	case "desc":
+		return items.OrderByDescending(selector)
+	default:
+		return items.AsOrderable()
+	}
+}
+
+func (items *Orderable) ThenBy(selector func(object interface{}) interface{}) *Orderable {
+	return items.thenBy(orderKey{selector: selector})
+}
+
+func (items *Orderable) ThenByDescending(selector func(object interface{}) interface{}) *Orderable {
+	return items.thenBy(orderKey{selector: selector, descending: true})
+}
+
+func (items *Orderable) ThenByFunc(less func(a, b interface{}) bool) *Orderable {
+	return items.ThenByComparer(identity, LessComparer(less))
+}
+
+func (items *Orderable) ThenByComparer(selector func(object interface{}) interface{}, comparer Comparer) *Orderable {
+	return items.thenBy(orderKey{selector: selector, comparer: comparer})
+}
+
+func (items *Orderable) ThenByDescendingComparer(selector func(object interface{}) interface{}, comparer Comparer) *Orderable {
+	return items.thenBy(orderKey{selector: selector, comparer: comparer, descending: true})
+}
+
+func (items *Orderable) Unwrap() Filterable {
+	return append(Filterable{}, items.items...)
+}
+
+func (items *Orderable) AsFilterable() *Filterable {
+	projection := append(Filterable{}, items.items...)
+	return &projection
+}
+
+func (items *Orderable) thenBy(key orderKey) *Orderable {
+	keys := append([]orderKey{}, items.keys...)
+	return newOrderable(items.source, append(keys, key))
+}
+
+func newOrderable(source Filterable, keys []orderKey) *Orderable {
+	// later changes to the caller's slice must not reach ThenBy
+	source = append(Filterable{}, source...)
+
+	// each selector runs once per element rather than once per comparison
+	selected := make([][]interface{}, len(keys))
+
+	for level, key := range keys {
+		selected[level] = make([]interface{}, len(source))
+
+		for index, item := range source {
+			selected[level][index] = key.selector(item)
+		}
+	}
+
+	positions := make([]int, len(source))
+
+	for index := range positions {
+		positions[index] = index
+	}
+
+	sort.SliceStable(positions, func(i, j int) bool {
+		for level, key := range keys {
+			a, b := selected[level][positions[i]], selected[level][positions[j]]
+
+			if key.nulls != nullsDefault && (a == nil) != (b == nil) {
+				return (a == nil) == (key.nulls == nullsFirst)
+			}
+
+			result := key.compare(a, b)
+
+			if result == 0 {
+				continue
+			}
+
+			return (result < 0) != key.descending
+		}
+
+		return false
+	})
+
+	items := make(Filterable, len(source))
+
+	for index, position := range positions {
+		items[index] = source[position]
+	}
+
+	return &Orderable{source: source, keys: keys, items: items}
+}
@@ -0,0 +1,296 @@
+package filterable
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type employee struct {
+	Department string
+	LastName   string
+	Age        int
+}
+
+var employees = []employee{
+	{"Sales", "Smith", 40},
+	{"IT", "Jones", 30},
+	{"Sales", "Adams", 25},
+	{"IT", "Jones", 22},
+	{"IT", "Brown", 35},
+}
+
+func Test_Orderable_ThenBy(t *testing.T) {
+	byDepartment := func(value interface{}) interface{} { return value.(employee).Department }
+	byLastName := func(value interface{}) interface{} { return value.(employee).LastName }
+	byAge := func(value interface{}) interface{} { return value.(employee).Age }
+
+	scenarios := []testScenario{
+		{
+			name:  "when sorting by several keys",
+			input: employees,
+			expected: format_any([]employee{
+				{"IT", "Brown", 35}, {"IT", "Jones", 22}, {"IT", "Jones", 30},
+				{"Sales", "Adams", 25}, {"Sales", "Smith", 40},
+			}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				result := collection.OrderBy(byDepartment).ThenBy(byLastName).ThenBy(byAge)
+				return format_any(result.Unwrap()), err
+			},
+		},
+		{
+			name:  "when a tie-breaker is descending",
+			input: employees,
+			expected: format_any([]employee{
+				{"IT", "Jones", 30}, {"IT", "Jones", 22}, {"IT", "Brown", 35},
+				{"Sales", "Smith", 40}, {"Sales", "Adams", 25},
+			}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				result := collection.OrderBy(byDepartment).ThenByDescending(byLastName)
+				return format_any(result.Unwrap()), err
+			},
+		},
+		{
+			name:  "when the primary key is descending",
+			input: employees,
+			expected: format_any([]employee{
+				{"Sales", "Adams", 25}, {"Sales", "Smith", 40},
+				{"IT", "Brown", 35}, {"IT", "Jones", 30}, {"IT", "Jones", 22},
+			}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				result := collection.OrderByDescending(byDepartment).ThenBy(byLastName)
+				return format_any(result.Unwrap()), err
+			},
+		},
+		{
+			name:  "when tie-breakers leave equal elements in source order",
+			input: employees,
+			expected: format_any([]employee{
+				{"IT", "Brown", 35}, {"IT", "Jones", 30}, {"IT", "Jones", 22},
+				{"Sales", "Adams", 25}, {"Sales", "Smith", 40},
+			}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				result := collection.OrderBy(byDepartment).ThenBy(byLastName)
+				return format_any(result.Unwrap()), err
+			},
+		},
+		{
+			name:     "when branching from the same ordering",
+			input:    employees,
+			expected: format_any([][]string{{"Brown", "Jones", "Jones", "Adams", "Smith"}, {"Jones", "Jones", "Brown", "Smith", "Adams"}}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				ordered := collection.OrderBy(byDepartment)
+				return format_any([]Filterable{
+					ordered.ThenBy(byLastName).AsFilterable().Select(byLastName).Unwrap(),
+					ordered.ThenByDescending(byLastName).AsFilterable().Select(byLastName).Unwrap(),
+				}), err
+			},
+		},
+		{
+			name:     "when the source or result is changed afterwards",
+			input:    []int{3, 1, 2},
+			expected: format_any([][]int{{1, 2, 3}, {1, 2, 3}}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				ordered := collection.OrderBy(identity)
+				(*collection)[0] = 100
+				unwrapped := ordered.Unwrap()
+				unwrapped[0] = -1
+				return format_any([]Filterable{ordered.ThenByDescending(identity).Unwrap(), ordered.Unwrap()}), err
+			},
+		},
+	}
+
+	run_tests_on("ThenBy", scenarios, t)
+}
+
+type version struct {
+	Major, Minor int
+}
+
+func (v version) Less(other version) bool {
+	return v.Major < other.Major || v.Major == other.Major && v.Minor < other.Minor
+}
+
+type priority int
+
+func (p priority) Compare(other priority) int {
+	return int(other) - int(p)
+}
+
+func Test_Orderable_KeyComparison(t *testing.T) {
+	identity := func(value interface{}) interface{} { return value }
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	scenarios := []testScenario{
+		{
+			name:     "when sorting multi-digit numbers",
+			input:    []int{9, 10, 100, 1, -5},
+			expected: format_any([]int{-5, 1, 9, 10, 100}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.OrderBy(identity).Unwrap()), err
+			},
+		},
+		{
+			name:     "when sorting mixed numeric kinds",
+			input:    []interface{}{2.5, uint8(3), int64(-1), float32(0.5), uint64(1 << 63)},
+			expected: format_any([]interface{}{int64(-1), float32(0.5), 2.5, uint8(3), uint64(1 << 63)}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.OrderBy(identity).Unwrap()), err
+			},
+		},
+		{
+			name:     "when sorting times",
+			input:    []time.Time{now.Add(time.Hour), now.Add(-time.Hour), now},
+			expected: format_any([]time.Time{now.Add(-time.Hour), now, now.Add(time.Hour)}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.OrderBy(identity).Unwrap()), err
+			},
+		},
+		{
+			name:     "when sorting booleans and nils",
+			input:    []interface{}{true, nil, false},
+			expected: format_any([]interface{}{nil, false, true}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.OrderBy(identity).Unwrap()), err
+			},
+		},
+		{
+			name:     "when keys implement Less",
+			input:    []version{{1, 10}, {1, 9}, {0, 20}},
+			expected: format_any([]version{{0, 20}, {1, 9}, {1, 10}}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.OrderBy(identity).Unwrap()), err
+			},
+		},
+		{
+			name:     "when keys implement Compare",
+			input:    []priority{1, 3, 2},
+			expected: format_any([]priority{3, 2, 1}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.OrderBy(identity).Unwrap()), err
+			},
+		},
+		{
+			name:     "when keys have unrelated types",
+			input:    []interface{}{"b", 2, "a", 1},
+			expected: format_any([]interface{}{1, 2, "a", "b"}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.OrderBy(identity).Unwrap()), err
+			},
+		},
+		{
+			name:     "when mixed numbers and strings start in any order",
+			input:    [][]interface{}{{5, "a", uint8(1)}, {uint8(1), 5, "a"}, {"a", uint8(1), 5}, {"a", 5, uint8(1)}},
+			expected: format_any([][]interface{}{{uint8(1), 5, "a"}, {uint8(1), 5, "a"}, {uint8(1), 5, "a"}, {uint8(1), 5, "a"}}),
+			action: func(input interface{}) (string, error) {
+				sorted := []Filterable{}
+				for _, keys := range input.([][]interface{}) {
+					collection, err := New(keys)
+					if err != nil {
+						return "", err
+					}
+					sorted = append(sorted, collection.OrderBy(identity).Unwrap())
+				}
+				return format_any(sorted), nil
+			},
+		},
+		{
+			name:     "when the selector is expensive",
+			input:    Range(1, 100),
+			expected: format_any(100),
+			action: func(input interface{}) (string, error) {
+				calls := 0
+				input.(*Filterable).OrderByDescending(func(value interface{}) interface{} {
+					calls++
+					return value
+				})
+				return format_any(calls), nil
+			},
+		},
+	}
+
+	run_tests_on("KeyComparison", scenarios, t)
+}
+
+func Test_Orderable_Comparers(t *testing.T) {
+	caseInsensitive := ComparerFunc(func(a, b interface{}) int {
+		return strings.Compare(strings.ToLower(a.(string)), strings.ToLower(b.(string)))
+	})
+	byLength := func(a, b interface{}) bool {
+		return len(a.(string)) < len(b.(string))
+	}
+
+	scenarios := []testScenario{
+		{
+			name:     "when ordering with a less function",
+			input:    []string{"ccc", "a", "bb"},
+			expected: format_any([]string{"a", "bb", "ccc"}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.OrderByFunc(byLength).Unwrap()), err
+			},
+		},
+		{
+			name:     "when ordering keys with a comparer",
+			input:    []string{"bob", "Alice", "alan", "Bea"},
+			expected: format_any([]string{"alan", "Alice", "Bea", "bob"}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.OrderByComparer(identity, caseInsensitive).Unwrap()), err
+			},
+		},
+		{
+			name:     "when ordering keys with a comparer in descending",
+			input:    []string{"bob", "Alice", "alan", "Bea"},
+			expected: format_any([]string{"bob", "Bea", "Alice", "alan"}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.OrderByDescendingComparer(identity, caseInsensitive).Unwrap()), err
+			},
+		},
+		{
+			name:     "when breaking ties with a less function",
+			input:    []string{"bb", "B", "a", "AA"},
+			expected: format_any([]string{"a", "AA", "B", "bb"}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				firstLetter := func(value interface{}) interface{} {
+					return strings.ToLower(value.(string)[:1])
+				}
+				return format_any(collection.OrderBy(firstLetter).ThenByFunc(byLength).Unwrap()), err
+			},
+		},
+		{
+			name:     "when breaking ties with comparers",
+			input:    employees,
+			expected: format_any([]string{"Jones", "Jones", "Brown", "Smith", "Adams"}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				department := func(value interface{}) interface{} { return value.(employee).Department }
+				lastName := func(value interface{}) interface{} { return value.(employee).LastName }
+				result := collection.
+					OrderByComparer(department, caseInsensitive).
+					ThenByDescendingComparer(lastName, caseInsensitive).
+					ThenByComparer(func(value interface{}) interface{} { return value.(employee).Age }, LessComparer(func(a, b interface{}) bool {
+						return a.(int) > b.(int)
+					}))
+				return format_any(result.AsFilterable().Select(lastName).Unwrap()), err
+			},
+		},
+	}
+
+	run_tests_on("Comparers", scenarios, t)
+}
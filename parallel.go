@@ -0,0 +1,331 @@
+package filterable
+
+import (
+	"context"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+type parallelStage struct {
+	predicate func(interface{}) bool
+	selector  func(interface{}) interface{}
+	take      int
+}
+
+type indexed struct {
+	index int
+	value interface{}
+}
+
+// Parallel is a deferred query whose Where and Select callbacks run across
+// goroutines when a terminal operation is called. Consecutive stages are
+// fused, so each element goes through all of them on one worker.
+//
+// Results keep the source order unless AsUnordered is used. Take and First
+// always follow the source order, so they are deterministic either way. A
+// panic in a callback stops the workers and is re-raised on the calling
+// goroutine. The ...Context terminals stop the workers once ctx is done and
+// return ctx.Err().
+type Parallel struct {
+	source  Filterable
+	stages  []parallelStage
+	workers int
+	ordered bool
+}
+
+// AsParallel runs the query on the given number of workers, or on
+// GOMAXPROCS workers when workers is not positive.
+func (items *Filterable) AsParallel(workers int) *Parallel {
+	return (&Parallel{source: *items, ordered: true}).WithDegreeOfParallelism(workers)
+}
+
+func (query *Parallel) WithDegreeOfParallelism(workers int) *Parallel {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	projection := *query
+	projection.workers = workers
+
+	return &projection
+}
+
+func (query *Parallel) AsOrdered() *Parallel {
+	projection := *query
+	projection.ordered = true
+
+	return &projection
+}
+
+// AsUnordered lets Unwrap return results in the order the workers finish them.
+func (query *Parallel) AsUnordered() *Parallel {
+	projection := *query
+	projection.ordered = false
+
+	return &projection
+}
+
+func (query *Parallel) Where(predicate func(interface{}) bool) *Parallel {
+	return query.stage(parallelStage{predicate: predicate})
+}
+
+func (query *Parallel) Select(selector func(interface{}) interface{}) *Parallel {
+	return query.stage(parallelStage{selector: selector})
+}
+
+func (query *Parallel) Take(count int) *Parallel {
+	if count < 0 {
+		count = 0
+	}
+
+	return query.stage(parallelStage{take: count})
+}
+
+func (query *Parallel) stage(stage parallelStage) *Parallel {
+	projection := *query
+	projection.stages = append(append([]parallelStage{}, query.stages...), stage)
+
+	return &projection
+}
+
+func (query *Parallel) Unwrap() Filterable {
+	projection, _ := query.UnwrapContext(context.Background())
+	return projection
+}
+
+func (query *Parallel) UnwrapContext(ctx context.Context) (Filterable, error) {
+	results, err := query.execute(ctx, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	projection := make(Filterable, len(results))
+
+	for index, result := range results {
+		projection[index] = result.value
+	}
+
+	return projection, nil
+}
+
+func (query *Parallel) AsFilterable() *Filterable {
+	projection := query.Unwrap()
+	return &projection
+}
+
+// AsSequential runs the query and returns its results for sequential use.
+func (query *Parallel) AsSequential() *Filterable {
+	return query.AsFilterable()
+}
+
+func (query *Parallel) Count() int {
+	count, _ := query.CountContext(context.Background())
+	return count
+}
+
+func (query *Parallel) CountContext(ctx context.Context) (int, error) {
+	results, err := query.execute(ctx, false)
+	return len(results), err
+}
+
+func (query *Parallel) First() interface{} {
+	first, _ := query.FirstContext(context.Background())
+	return first
+}
+
+// TryFirst is First that tells "no element" apart from a nil element.
+func (query *Parallel) TryFirst() (interface{}, bool) {
+	first, err := query.FirstContext(context.Background())
+	return first, err == nil
+}
+
+// FirstContext returns the first element, or ErrNoElements if there is none.
+func (query *Parallel) FirstContext(ctx context.Context) (interface{}, error) {
+	results, err := query.execute(ctx, true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 {
+		return nil, ErrNoElements
+	}
+
+	return results[0].value, nil
+}
+
+func (query *Parallel) Any(predicate func(interface{}) bool) bool {
+	found, _ := query.AnyContext(context.Background(), predicate)
+	return found
+}
+
+func (query *Parallel) AnyContext(ctx context.Context, predicate func(interface{}) bool) (bool, error) {
+	results, err := query.Where(predicate).execute(ctx, true)
+	return len(results) > 0, err
+}
+
+func (query *Parallel) All(predicate func(interface{}) bool) bool {
+	all, _ := query.AllContext(context.Background(), predicate)
+	return all
+}
+
+func (query *Parallel) AllContext(ctx context.Context, predicate func(interface{}) bool) (bool, error) {
+	found, err := query.AnyContext(ctx, func(value interface{}) bool {
+		return !predicate(value)
+	})
+
+	return !found && err == nil, err
+}
+
+// execute runs the stages, treating each Take as a barrier that needs the
+// preceding results in source order. With firstOnly it stops once the
+// earliest surviving element is known.
+func (query *Parallel) execute(ctx context.Context, firstOnly bool) ([]indexed, error) {
+	items := make([]indexed, len(query.source))
+
+	for index, item := range query.source {
+		items[index] = indexed{index: index, value: item}
+	}
+
+	segment := []parallelStage{}
+
+	for _, stage := range query.stages {
+		if stage.predicate != nil || stage.selector != nil {
+			segment = append(segment, stage)
+			continue
+		}
+
+		var err error
+
+		if items, err = query.run(ctx, items, segment, false); err != nil {
+			return nil, err
+		}
+
+		sortByIndex(items)
+
+		if stage.take < len(items) {
+			items = items[:stage.take]
+		}
+
+		segment = []parallelStage{}
+	}
+
+	results, err := query.run(ctx, items, segment, firstOnly)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if query.ordered || firstOnly {
+		sortByIndex(results)
+	}
+
+	if firstOnly && len(results) > 1 {
+		results = results[:1]
+	}
+
+	return results, nil
+}
+
+// run applies stages to items, which must be in source order.
+func (query *Parallel) run(ctx context.Context, items []indexed, stages []parallelStage, firstOnly bool) ([]indexed, error) {
+	workers := query.workers
+
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	var (
+		next    int64 = -1
+		best          = int64(len(items))
+		halted  int32
+		failure interface{}
+		once    sync.Once
+		group   sync.WaitGroup
+	)
+
+	buffers := make([][]indexed, workers)
+
+	for worker := 0; worker < workers; worker++ {
+		group.Add(1)
+
+		go func(worker int) {
+			defer group.Done()
+
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					once.Do(func() { failure = recovered })
+					atomic.StoreInt32(&halted, 1)
+				}
+			}()
+
+			for {
+				position := atomic.AddInt64(&next, 1)
+
+				// positions are handed out in increasing order, so once one is past
+				// the earliest match every later one is too
+				if position >= int64(len(items)) || atomic.LoadInt32(&halted) == 1 ||
+					firstOnly && position > atomic.LoadInt64(&best) || ctx.Err() != nil {
+					return
+				}
+
+				value, kept := applyStages(stages, items[position].value)
+
+				if !kept {
+					continue
+				}
+
+				buffers[worker] = append(buffers[worker], indexed{index: items[position].index, value: value})
+
+				for current := atomic.LoadInt64(&best); firstOnly && position < current; current = atomic.LoadInt64(&best) {
+					if atomic.CompareAndSwapInt64(&best, current, position) {
+						break
+					}
+				}
+			}
+		}(worker)
+	}
+
+	group.Wait()
+
+	if failure != nil {
+		panic(failure)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	results := []indexed{}
+
+	for _, buffer := range buffers {
+		results = append(results, buffer...)
+	}
+
+	return results, nil
+}
+
+func applyStages(stages []parallelStage, value interface{}) (interface{}, bool) {
+	for _, stage := range stages {
+		if stage.predicate != nil && !stage.predicate(value) {
+			return nil, false
+		}
+
+		if stage.selector != nil {
+			if value = stage.selector(value); value == empty {
+				return nil, false
+			}
+		}
+	}
+
+	return value, true
+}
+
+func sortByIndex(items []indexed) {
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].index < items[j].index
+	})
+}
@@ -0,0 +1,237 @@
+package filterable
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+)
+
+func Test_Parallel_Where(t *testing.T) {
+	isOdd := func(value interface{}) bool { return value.(int)%2 == 1 }
+	square := func(value interface{}) interface{} { return value.(int) * value.(int) }
+
+	scenarios := []testScenario{
+		{
+			name:     "when an empty slice is given",
+			input:    emptyInput,
+			expected: format_any([]int{}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.AsParallel(4).Where(isOdd).Unwrap()), err
+			},
+		},
+		{
+			name:     "when the results keep the source order",
+			input:    Range(1, 1000),
+			expected: format_any(Range(1, 1000).Where(isOdd).Select(square).Unwrap()),
+			action: func(input interface{}) (string, error) {
+				return format_any(input.(*Filterable).AsParallel(8).Where(isOdd).Select(square).Unwrap()), nil
+			},
+		},
+		{
+			name:     "when the results are unordered",
+			input:    Range(1, 1000),
+			expected: format_any(Range(1, 1000).Where(isOdd).Select(square).Unwrap()),
+			action: func(input interface{}) (string, error) {
+				result := input.(*Filterable).AsParallel(8).AsUnordered().Where(isOdd).Select(square).AsSequential()
+				return format_any(result.OrderBy(identity).Unwrap()), nil
+			},
+		},
+		{
+			name:     "when the degree of parallelism is defaulted",
+			input:    sliceInput,
+			expected: format_any([]int{1, 9, 25, 49}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				query := collection.AsParallel(0).WithDegreeOfParallelism(-1).AsUnordered().AsOrdered()
+				return format_any(query.Where(isOdd).Select(square).Unwrap()), err
+			},
+		},
+		{
+			name:     "when selecting Empty() for some values",
+			input:    sliceInput,
+			expected: format_any([]int{1, 3, 5, 7}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				query := collection.AsParallel(3).Select(func(value interface{}) interface{} {
+					if !isOdd(value) {
+						return Empty()
+					}
+					return value
+				})
+				return format_any(query.Unwrap()), err
+			},
+		},
+	}
+
+	run_tests_on("Where", scenarios, t)
+}
+
+func Test_Parallel_OrderSensitive(t *testing.T) {
+	isMultipleOf7 := func(value interface{}) bool { return value.(int)%7 == 0 }
+
+	scenarios := []testScenario{
+		{
+			name:     "when taking from an unordered query",
+			input:    Range(1, 1000),
+			expected: format_any([]int{7, 14, 21}),
+			action: func(input interface{}) (string, error) {
+				query := input.(*Filterable).AsParallel(8).AsUnordered().Where(isMultipleOf7).Take(3)
+				return format_any(query.AsOrdered().Unwrap()), nil
+			},
+		},
+		{
+			name:     "when filtering after taking",
+			input:    Range(1, 1000),
+			expected: format_any([]int{7, 14}),
+			action: func(input interface{}) (string, error) {
+				query := input.(*Filterable).AsParallel(8).Take(20).Where(isMultipleOf7)
+				return format_any(query.Unwrap()), nil
+			},
+		},
+		{
+			name:     "when a negative count is taken",
+			input:    sliceInput,
+			expected: format_any([]int{}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				return format_any(collection.AsParallel(2).Take(-1).Unwrap()), err
+			},
+		},
+		{
+			name:     "when finding the first match",
+			input:    Range(1, 10000),
+			expected: format_any([]interface{}{7, nil}),
+			action: func(input interface{}) (string, error) {
+				query := input.(*Filterable).AsParallel(8).AsUnordered()
+				return format_any([]interface{}{
+					query.Where(isMultipleOf7).First(),
+					query.Where(func(value interface{}) bool { return value.(int) < 0 }).First(),
+				}), nil
+			},
+		},
+	}
+
+	run_tests_on("OrderSensitive", scenarios, t)
+}
+
+func Test_Parallel_Terminals(t *testing.T) {
+	isPositive := func(value interface{}) bool { return value.(int) > 0 }
+
+	scenarios := []testScenario{
+		{
+			name:     "when an empty slice is given",
+			input:    emptyInput,
+			expected: format_any([]interface{}{0, false, true, nil}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				query := collection.AsParallel(4)
+				return format_any([]interface{}{query.Count(), query.Any(isPositive), query.All(isPositive), query.First()}), err
+			},
+		},
+		{
+			name:     "when a non-empty slice is given",
+			input:    Range(-5, 1000),
+			expected: format_any([]interface{}{994, true, false, -5}),
+			action: func(input interface{}) (string, error) {
+				query := input.(*Filterable).AsParallel(4)
+				return format_any([]interface{}{query.Where(isPositive).Count(), query.Any(isPositive), query.All(isPositive), query.First()}), nil
+			},
+		},
+		{
+			name:     "when the first element is missing or nil",
+			input:    []interface{}{nil, 1},
+			expected: format_any([]interface{}{nil, true, nil, false, ErrNoElements}),
+			action: func(input interface{}) (string, error) {
+				collection, err := New(input)
+				query := collection.AsParallel(2)
+				first, found := query.TryFirst()
+				missing, ok := query.Where(func(value interface{}) bool { return value == 2 }).TryFirst()
+				_, noneErr := query.Take(0).FirstContext(context.Background())
+				return format_any([]interface{}{first, found, missing, ok, noneErr}), err
+			},
+		},
+		{
+			name:     "when every callback runs concurrently",
+			input:    Range(0, 100),
+			expected: format_any(100),
+			action: func(input interface{}) (string, error) {
+				var calls int64
+				input.(*Filterable).AsParallel(8).Select(func(value interface{}) interface{} {
+					atomic.AddInt64(&calls, 1)
+					return value
+				}).Count()
+				return format_any(atomic.LoadInt64(&calls)), nil
+			},
+		},
+		{
+			name:     "when a callback panics",
+			input:    []interface{}{1, "two", 3},
+			expected: format_any(true),
+			action: func(input interface{}) (panicked string, err error) {
+				collection, _ := New(input)
+				defer func() {
+					panicked = format_any(recover() != nil)
+				}()
+				collection.AsParallel(2).Where(isPositive).Unwrap()
+				return
+			},
+		},
+	}
+
+	run_tests_on("Terminals", scenarios, t)
+}
+
+func Test_Parallel_Context(t *testing.T) {
+	isEven := func(value interface{}) bool { return value.(int)%2 == 0 }
+
+	scenarios := []testScenario{
+		{
+			name:     "when the context is never cancelled",
+			input:    Range(1, 100),
+			expected: format_any([]interface{}{50, 2, true, false}),
+			action: func(input interface{}) (string, error) {
+				ctx := context.Background()
+				query := input.(*Filterable).AsParallel(4)
+				count, _ := query.Where(isEven).CountContext(ctx)
+				first, _ := query.Where(isEven).FirstContext(ctx)
+				found, _ := query.AnyContext(ctx, isEven)
+				all, err := query.AllContext(ctx, isEven)
+				return format_any([]interface{}{count, first, found, all}), err
+			},
+		},
+		{
+			name:     "when the context is already cancelled",
+			input:    Range(1, 100),
+			expected: format_any([]interface{}{context.Canceled, context.Canceled, context.Canceled}),
+			action: func(input interface{}) (string, error) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				query := input.(*Filterable).AsParallel(4)
+				_, unwrapErr := query.UnwrapContext(ctx)
+				_, countErr := query.Take(10).CountContext(ctx)
+				_, allErr := query.AllContext(ctx, isEven)
+				return format_any([]interface{}{unwrapErr, countErr, allErr}), nil
+			},
+		},
+		{
+			name:     "when the context is cancelled part way",
+			input:    Range(0, 1_000_000),
+			expected: format_any([]interface{}{context.Canceled, true}),
+			action: func(input interface{}) (string, error) {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				var calls int64
+				_, err := input.(*Filterable).AsParallel(4).Select(func(value interface{}) interface{} {
+					if atomic.AddInt64(&calls, 1) == 100 {
+						cancel()
+					}
+					return value
+				}).UnwrapContext(ctx)
+				return format_any([]interface{}{err, atomic.LoadInt64(&calls) < 1_000_000}), nil
+			},
+		},
+	}
+
+	run_tests_on("Context", scenarios, t)
+}
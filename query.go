@@ -0,0 +1,147 @@
+package filterable
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Query is a type-safe counterpart to Filterable. Methods that change the
+// element type, such as Select, are package-level functions because Go does
+// not allow type parameters on methods.
+type Query[T any] struct {
+	items []T
+}
+
+func From[T any](items []T) *Query[T] {
+	projection := make([]T, len(items))
+	copy(projection, items)
+
+	return &Query[T]{items: projection}
+}
+
+// FromFilterable converts items to a Query, failing on the first element that
+// is not a T.
+func FromFilterable[T any](items *Filterable) (*Query[T], error) {
+	projection := make([]T, len(*items))
+
+	for index, item := range *items {
+		value, ok := item.(T)
+
+		if !ok && (item != nil || !isNillable(reflect.TypeOf((*T)(nil)).Elem())) {
+			return nil, fmt.Errorf("element %d is %T, not %v", index, item, reflect.TypeOf((*T)(nil)).Elem())
+		}
+
+		projection[index] = value
+	}
+
+	return &Query[T]{items: projection}, nil
+}
+
+func (query *Query[T]) AsFilterable() *Filterable {
+	filterable := make(Filterable, len(query.items))
+
+	for index, item := range query.items {
+		filterable[index] = item
+	}
+
+	return &filterable
+}
+
+func (query *Query[T]) ToSlice() []T {
+	projection := make([]T, len(query.items))
+	copy(projection, query.items)
+
+	return projection
+}
+
+func (query *Query[T]) Where(predicate func(T) bool) *Query[T] {
+	return query.WhereIndexed(func(_ int, value T) bool {
+		return predicate(value)
+	})
+}
+
+func (query *Query[T]) WhereIndexed(predicate func(int, T) bool) *Query[T] {
+	projection := []T{}
+
+	for index, item := range query.items {
+		if predicate(index, item) {
+			projection = append(projection, item)
+		}
+	}
+
+	return &Query[T]{items: projection}
+}
+
+func Select[T, U any](query *Query[T], selector func(T) U) *Query[U] {
+	return SelectIndexed(query, func(_ int, value T) U {
+		return selector(value)
+	})
+}
+
+func SelectIndexed[T, U any](query *Query[T], selector func(int, T) U) *Query[U] {
+	projection := make([]U, len(query.items))
+
+	for index, item := range query.items {
+		projection[index] = selector(index, item)
+	}
+
+	return &Query[U]{items: projection}
+}
+
+func (query *Query[T]) Skip(count int) *Query[T] {
+	if count >= 0 && len(query.items) > count {
+		return &Query[T]{items: query.items[count:]}
+	}
+
+	return &Query[T]{items: []T{}}
+}
+
+func (query *Query[T]) Take(count int) *Query[T] {
+	if count <= 0 {
+		return &Query[T]{items: []T{}}
+	}
+
+	if count < len(query.items) {
+		return &Query[T]{items: query.items[:count]}
+	}
+
+	return query
+}
+
+func (query *Query[T]) Any(predicate func(T) bool) bool {
+	for _, item := range query.items {
+		if predicate(item) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (query *Query[T]) All(predicate func(T) bool) bool {
+	return !query.Any(func(value T) bool {
+		return !predicate(value)
+	})
+}
+
+func (query *Query[T]) First() (T, bool) {
+	if len(query.items) > 0 {
+		return query.items[0], true
+	}
+
+	var zero T
+	return zero, false
+}
+
+func (query *Query[T]) Count() int {
+	return len(query.items)
+}
+
+func isNillable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,175 @@
+package filterable
+
+import (
+	"testing"
+)
+
+func Test_Query_From(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when a slice is given",
+			input:    sliceInput,
+			expected: format_any(sliceInput),
+			action: func(input interface{}) (string, error) {
+				return format_any(From(input.([]int)).ToSlice()), nil
+			},
+		},
+		{
+			name:     "when the source slice is modified afterwards",
+			input:    []int{1, 2, 3},
+			expected: format_any([]int{1, 2, 3}),
+			action: func(input interface{}) (string, error) {
+				values := input.([]int)
+				query := From(values)
+				values[0] = 42
+				return format_any(query.ToSlice()), nil
+			},
+		},
+	}
+
+	run_tests_on("From", scenarios, t)
+}
+
+func Test_Query_FromFilterable(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when all elements have the expected type",
+			input:    sliceInput,
+			expected: format_any(sliceInput),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				query, err := FromFilterable[int](collection)
+				return format_any(query.ToSlice()), err
+			},
+		},
+		{
+			name:     "when an element has a different type",
+			input:    []interface{}{1, "two", 3},
+			expected: format_any("element 1 is string, not int"),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				_, err := FromFilterable[int](collection)
+				return format_any(err), nil
+			},
+		},
+		{
+			name:     "when a nil element is converted to a pointer type",
+			input:    []interface{}{nil},
+			expected: format_any([]*int{nil}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				query, err := FromFilterable[*int](collection)
+				return format_any(query.ToSlice()), err
+			},
+		},
+		{
+			name:     "when converting back to a filterable",
+			input:    sliceInput,
+			expected: format_any(sliceInput),
+			action: func(input interface{}) (string, error) {
+				return format_any(From(input.([]int)).AsFilterable().Unwrap()), nil
+			},
+		},
+	}
+
+	run_tests_on("FromFilterable", scenarios, t)
+}
+
+func Test_Query_Where(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when filtering odd numbers",
+			input:    sliceInput,
+			expected: format_any([]int{1, 3, 5, 7}),
+			action: func(input interface{}) (string, error) {
+				query := From(input.([]int)).Where(func(value int) bool {
+					return value%2 == 1
+				})
+				return format_any(query.ToSlice()), nil
+			},
+		},
+		{
+			name:     "when filtering by index",
+			input:    sliceInput,
+			expected: format_any([]int{1, 2}),
+			action: func(input interface{}) (string, error) {
+				query := From(input.([]int)).WhereIndexed(func(index int, _ int) bool {
+					return index < 2
+				})
+				return format_any(query.ToSlice()), nil
+			},
+		},
+	}
+
+	run_tests_on("Where", scenarios, t)
+}
+
+func Test_Query_Select(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when projecting into a new type",
+			input:    []int{1, 2, 3},
+			expected: format_any([]string{"#1", "#2", "#3"}),
+			action: func(input interface{}) (string, error) {
+				query := Select(From(input.([]int)), func(value int) string {
+					return "#" + format_any(value)
+				})
+				return format_any(query.ToSlice()), nil
+			},
+		},
+	}
+
+	run_tests_on("Select", scenarios, t)
+}
+
+func Test_Query_Partitioning(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when skipping and taking",
+			input:    sliceInput,
+			expected: format_any([]int{3, 4}),
+			action: func(input interface{}) (string, error) {
+				return format_any(From(input.([]int)).Skip(2).Take(2).ToSlice()), nil
+			},
+		},
+		{
+			name:     "when out of range counts are given",
+			input:    sliceInput,
+			expected: format_any([]int{}),
+			action: func(input interface{}) (string, error) {
+				return format_any(From(input.([]int)).Skip(10).Take(-1).ToSlice()), nil
+			},
+		},
+	}
+
+	run_tests_on("Partitioning", scenarios, t)
+}
+
+func Test_Query_Terminals(t *testing.T) {
+	scenarios := []testScenario{
+		{
+			name:     "when querying an empty sequence",
+			input:    emptyInput,
+			expected: format_any([]interface{}{0, false, 0, false, true}),
+			action: func(input interface{}) (string, error) {
+				query := From(input.([]int))
+				first, ok := query.First()
+				isOdd := func(value int) bool { return value%2 == 1 }
+				return format_any([]interface{}{first, ok, query.Count(), query.Any(isOdd), query.All(isOdd)}), nil
+			},
+		},
+		{
+			name:     "when querying a non-empty sequence",
+			input:    sliceInput,
+			expected: format_any([]interface{}{1, true, 7, true, false}),
+			action: func(input interface{}) (string, error) {
+				query := From(input.([]int))
+				first, ok := query.First()
+				isOdd := func(value int) bool { return value%2 == 1 }
+				return format_any([]interface{}{first, ok, query.Count(), query.Any(isOdd), query.All(isOdd)}), nil
+			},
+		},
+	}
+
+	run_tests_on("Terminals", scenarios, t)
+}
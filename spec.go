@@ -0,0 +1,192 @@
+package filterable
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// OrderBySpec sorts by an ordering spec such as "LastName asc, Age desc",
+// the form a sort= query parameter usually takes. Each comma-separated term is
+// a field path, as accepted by Field, optionally followed by "asc" or "desc"
+// and then by "nulls first" or "nulls last". Keywords are case-insensitive and
+// the direction defaults to ascending. Pointer keys are compared by the value
+// they point to, and nil keys sort first ascending and last descending unless
+// a nulls clause says otherwise.
+//
+// Every path is checked against the type of every element, so a misspelled
+// field is reported even when the elements that would reach it are nil along
+// the way. A malformed term returns an error wrapping ErrInvalidOrder and a
+// path that does not fit returns a *FieldError. A blank spec leaves the
+// elements in source order.
+func (items *Filterable) OrderBySpec(spec string) (*Orderable, error) {
+	return newOrderableSpec(*items, nil, spec)
+}
+
+// ThenBySpec adds the terms of an ordering spec, as accepted by OrderBySpec,
+// as tie-breakers.
+func (items *Orderable) ThenBySpec(spec string) (*Orderable, error) {
+	return newOrderableSpec(items.source, items.keys, spec)
+}
+
+func newOrderableSpec(source Filterable, keys []orderKey, spec string) (*Orderable, error) {
+	parsed, err := parseOrderSpec(spec)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newOrderableTerms(source, keys, parsed)
+}
+
+// newOrderableTerms checks every term's path against the type of every element
+// before sorting by them after keys. Nil elements have no type to check, so an
+// empty or all-nil source accepts any path.
+func newOrderableTerms(source Filterable, keys []orderKey, terms []orderTerm) (*Orderable, error) {
+	checked := map[reflect.Type]bool{}
+
+	for _, item := range source {
+		owner := reflect.TypeOf(item)
+
+		if owner == nil || checked[owner] {
+			continue
+		}
+
+		for _, term := range terms {
+			if err := checkFieldPath(owner, term.path); err != nil {
+				return nil, err
+			}
+		}
+
+		checked[owner] = true
+	}
+
+	combined := append([]orderKey{}, keys...)
+
+	for _, term := range terms {
+		combined = append(combined, term.key)
+	}
+
+	// elements behind interfaces can still fail at selection time
+	return catchFieldError(func() *Orderable {
+		return newOrderable(source, combined)
+	})
+}
+
+type orderTerm struct {
+	path string
+	key  orderKey
+}
+
+func parseOrderSpec(spec string) ([]orderTerm, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+
+	terms := []orderTerm{}
+
+	for _, term := range strings.Split(spec, ",") {
+		words := strings.Fields(term)
+
+		if len(words) == 0 {
+			return nil, fmt.Errorf("%w %q: empty term", ErrInvalidOrder, spec)
+		}
+
+		path := words[0]
+
+		for _, segment := range strings.Split(path, ".") {
+			if segment == "" {
+				return nil, fmt.Errorf("%w %q: malformed field path %q", ErrInvalidOrder, strings.TrimSpace(term), path)
+			}
+		}
+
+		key := orderKey{selector: orderField(path)}
+		words = words[1:]
+
+		if len(words) > 0 {
+			switch strings.ToLower(words[0]) {
+			case "asc":
+				words = words[1:]
+			case "desc":
+				key.descending = true
+				words = words[1:]
+			}
+		}
+
+		if len(words) > 0 {
+			if len(words) != 2 || !strings.EqualFold(words[0], "nulls") {
+				return nil, fmt.Errorf("%w %q: unexpected %q", ErrInvalidOrder, strings.TrimSpace(term), strings.Join(words, " "))
+			}
+
+			switch strings.ToLower(words[1]) {
+			case "first":
+				key.nulls = nullsFirst
+			case "last":
+				key.nulls = nullsLast
+			default:
+				return nil, fmt.Errorf("%w %q: unexpected %q", ErrInvalidOrder, strings.TrimSpace(term), strings.Join(words, " "))
+			}
+		}
+
+		terms = append(terms, orderTerm{path: path, key: key})
+	}
+
+	return terms, nil
+}
+
+// orderField is Field with pointer keys replaced by what they point to, so
+// that a *string field sorts as a string and a nil one as nil.
+func orderField(path string) func(interface{}) interface{} {
+	field := Field(path)
+
+	return func(value interface{}) interface{} {
+		selected := reflect.ValueOf(field(value))
+
+		for selected.Kind() == reflect.Ptr {
+			if selected.IsNil() {
+				return nil
+			}
+
+			selected = selected.Elem()
+		}
+
+		if !selected.IsValid() {
+			return nil
+		}
+
+		return selected.Interface()
+	}
+}
+
+// checkFieldPath resolves path against the static type owner. Interfaces are
+// only known per element, so the check stops at the first one.
+func checkFieldPath(owner reflect.Type, path string) error {
+	for _, segment := range strings.Split(path, ".") {
+		for owner.Kind() == reflect.Ptr {
+			owner = owner.Elem()
+		}
+
+		switch owner.Kind() {
+		case reflect.Interface:
+			return nil
+		case reflect.Struct:
+			index, err := fieldIndex(owner, segment)
+
+			if err != nil {
+				return &FieldError{Path: path, Err: err}
+			}
+
+			owner = owner.FieldByIndex(index).Type
+		case reflect.Map:
+			if owner.Key().Kind() != reflect.String {
+				return &FieldError{Path: path, Err: fmt.Errorf("%w %q in %v", ErrUnknownField, segment, owner)}
+			}
+
+			owner = owner.Elem()
+		default:
+			return &FieldError{Path: path, Err: fmt.Errorf("%w %q in %v", ErrUnknownField, segment, owner)}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package filterable
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_Filterable_OrderBySpec(t *testing.T) {
+	names := func(ordered *Orderable) string {
+		names, _ := ordered.AsFilterable().Pluck("Name")
+		return format_any(names.Unwrap())
+	}
+
+	scenarios := []testScenario{
+		{
+			name:  "when sorting by several terms",
+			input: employees,
+			expected: format_any([]employee{
+				{"IT", "Brown", 35}, {"IT", "Jones", 30}, {"IT", "Jones", 22},
+				{"Sales", "Adams", 25}, {"Sales", "Smith", 40},
+			}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				ordered, err := collection.OrderBySpec("Department, LastName ASC, Age desc")
+				if err != nil {
+					return "", err
+				}
+				return format_any(ordered.Unwrap()), nil
+			},
+		},
+		{
+			name:     "when sorting by a nested pointer field",
+			input:    people,
+			expected: format_any([]string{"Abe", "Cleo", "Bea"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				ordered, err := collection.OrderBySpec("Address.Zip desc, Address.City desc")
+				if err != nil {
+					return "", err
+				}
+				return names(ordered), nil
+			},
+		},
+		{
+			name:     "when nils are placed last",
+			input:    people,
+			expected: format_any([]string{"Abe", "Cleo", "Bea"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				ordered, err := collection.OrderBySpec("Address.City nulls last")
+				if err != nil {
+					return "", err
+				}
+				return names(ordered), nil
+			},
+		},
+		{
+			name:     "when nils are placed first in descending",
+			input:    people,
+			expected: format_any([]string{"Bea", "Cleo", "Abe"}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				ordered, err := collection.OrderBySpec("Address.City desc NULLS FIRST")
+				if err != nil {
+					return "", err
+				}
+				return names(ordered), nil
+			},
+		},
+		{
+			name:     "when adding tie-breakers",
+			input:    employees,
+			expected: format_any([]int{22, 25, 30, 35, 40}),
+			action: func(input interface{}) (string, error) {
+				collection, _ := New(input)
+				ordered, err := collection.OrderBySpec("")
+				if err != nil {
+					return "", err
+				}
+				if ordered, err = ordered.ThenBySpec("Age"); err != nil {
+					return "", err
+				}
+				ages, err := ordered.AsFilterable().Pluck("Age")
+				return format_any(ages.Unwrap()), err
+			},
+		},
+	}
+
+	run_tests_on("OrderBySpec", scenarios, t)
+}
+
+func Test_Filterable_OrderBySpec_Errors(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		input    Filterable
+		spec     string
+		expected string
+		is       error
+	}{
+		{
+			name:     "when a direction is unknown",
+			spec:     "Name sideways",
+			expected: `invalid ordering "Name sideways": unexpected "sideways"`,
+			is:       ErrInvalidOrder,
+		},
+		{
+			name:     "when a nulls clause is incomplete",
+			spec:     "Name desc nulls",
+			expected: `invalid ordering "Name desc nulls": unexpected "nulls"`,
+			is:       ErrInvalidOrder,
+		},
+		{
+			name:     "when a term is empty",
+			spec:     "Name,,Age",
+			expected: `invalid ordering "Name,,Age": empty term`,
+			is:       ErrInvalidOrder,
+		},
+		{
+			name:     "when a path is malformed",
+			spec:     "Address..City",
+			expected: `invalid ordering "Address..City": malformed field path "Address..City"`,
+			is:       ErrInvalidOrder,
+		},
+		{
+			name:     "when an element has no such field",
+			input:    Filterable{people[0], 7},
+			spec:     "Age, Name",
+			expected: `field "Age": unknown field "Age" in int`,
+			is:       ErrUnknownField,
+		},
+		{
+			name:     "when a field is unknown behind a nil pointer",
+			spec:     "Address.Town",
+			expected: `field "Address.Town": unknown field "Town" in filterable.address`,
+			is:       ErrUnknownField,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			collection := scenario.input
+
+			if collection == nil {
+				collection = Filterable{people[2]}
+			}
+
+			ordered, err := collection.OrderBySpec(scenario.spec)
+
+			if ordered != nil || err == nil || err.Error() != scenario.expected || !errors.Is(err, scenario.is) {
+				t.Errorf("filterable.OrderBySpec(%q) = %v, %v, expected %v", scenario.spec, ordered, err, scenario.expected)
+			}
+		})
+	}
+}